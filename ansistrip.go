@@ -0,0 +1,62 @@
+package golog
+
+import "io"
+
+// ANSIStrippingWriter removes ANSI escape sequences from everything written
+// through it, e.g. to keep a plain file clean while colors are forced on
+// with SetColorEnabled for a terminal writer next to it. Sequences split
+// across Write calls are still removed. Like most writers it is not safe
+// for concurrent use; the logger serializes its own writes.
+type ANSIStrippingWriter struct {
+	w     io.Writer
+	state ansiState
+	buf   []byte
+}
+
+type ansiState int
+
+const (
+	ansiText   ansiState = iota
+	ansiEscape           // After ESC
+	ansiCSI              // After ESC [, until the final byte
+)
+
+// NewANSIStrippingWriter returns an ANSIStrippingWriter writing to w.
+func NewANSIStrippingWriter(w io.Writer) io.Writer {
+	return &ANSIStrippingWriter{w: w}
+}
+
+// Write writes p to the underlying writer without escape sequences. It
+// reports len(p) on success, since the stripped bytes count as written.
+func (s *ANSIStrippingWriter) Write(p []byte) (int, error) {
+	out := s.buf[:0]
+	for _, c := range p {
+		switch s.state {
+		case ansiText:
+			if c == 0x1b {
+				s.state = ansiEscape
+			} else {
+				out = append(out, c)
+			}
+		case ansiEscape:
+			if c == '[' {
+				s.state = ansiCSI
+			} else {
+				// Two-byte sequence such as ESC 7.
+				s.state = ansiText
+			}
+		case ansiCSI:
+			if c >= 0x40 && c <= 0x7e {
+				s.state = ansiText
+			}
+		}
+	}
+	s.buf = out
+	if len(out) == 0 {
+		return len(p), nil
+	}
+	if _, err := s.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
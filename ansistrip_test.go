@@ -0,0 +1,29 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestANSIStrippingWriter checks that colored output, including sequences
+// split across writes, reaches the file plain.
+func TestANSIStrippingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(NewANSIStrippingWriter(&buf)))
+	l.SetColorEnabled(true)
+	l.Error("disk full")
+	if expected := "[ERROR] disk full \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	w := NewANSIStrippingWriter(&buf)
+	for _, chunk := range []string{"a\033", "[38;5;", "208mb\033", "7c\033[0", "m\n"} {
+		if n, err := w.Write([]byte(chunk)); err != nil || n != len(chunk) {
+			t.Fatalf("write %q: n=%d err=%v", chunk, n, err)
+		}
+	}
+	if buf.String() != "abc\n" {
+		t.Errorf("expected split sequences to be stripped, got %q", buf.String())
+	}
+}
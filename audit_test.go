@@ -0,0 +1,84 @@
+package golog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestAudit checks that audit lines are numbered and mirrored to the main log regardless of level.
+func TestAudit(t *testing.T) {
+	var main, trail bytes.Buffer
+	l := NewLogger(WithOutput(&main))
+	l.SetAuditLog(&trail)
+
+	l.Audit("user %s granted admin", "bob")
+	l.WithTypedFields(Field{"actor", "alice"}).Audit("key rotated")
+	l.SetLevel(LevelError)
+	l.Audit("quiet")
+
+	lines := strings.Split(strings.TrimSuffix(trail.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 audit lines, got %q", trail.String())
+	}
+	for i, want := range []string{"1 ", "2 ", "3 "} {
+		if !strings.HasPrefix(lines[i], want) {
+			t.Errorf("expected line %d to start with sequence %q, got %q", i, want, lines[i])
+		}
+	}
+	if !strings.HasSuffix(lines[0], " user bob granted admin") || !strings.HasSuffix(lines[1], " key rotated actor=alice") {
+		t.Errorf("unexpected audit lines %q", lines)
+	}
+
+	if got, want := main.String(), "[INFO] [AUDIT] user bob granted admin \n"+"[INFO] [AUDIT] key rotated actor=alice \n"; got != want {
+		t.Errorf("expected main log %q, got %q", want, got)
+	}
+}
+
+// TestAuditMainLogFilters checks that filters and middleware apply to the main log copy only.
+func TestAuditMainLogFilters(t *testing.T) {
+	var main, trail bytes.Buffer
+	l := NewLogger(WithOutput(&main))
+	l.SetAuditLog(&trail)
+	wrapped := Wrap(l, func(e Entry) Entry {
+		e.Message = strings.ToUpper(e.Message)
+		return e
+	})
+
+	wrapped.Audit("key rotated")
+	l.SetCallerFilter([]string{"github.com/ryqdev/golog"}, Drop)
+	l.Audit("filtered")
+
+	if got, want := main.String(), "[INFO] [AUDIT] KEY ROTATED \n"; got != want {
+		t.Errorf("expected main log %q, got %q", want, got)
+	}
+	if !strings.Contains(trail.String(), " key rotated\n") || !strings.Contains(trail.String(), " filtered\n") {
+		t.Errorf("expected both messages unchanged in the audit log, got %q", trail.String())
+	}
+}
+
+// TestSetAuditFile checks that the audit file is appended to across opens.
+func TestSetAuditFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	os.WriteFile(path, []byte("existing\n"), 0644)
+
+	var main bytes.Buffer
+	l := NewLogger(WithOutput(&main))
+	if err := l.SetAuditFile(path); err != nil {
+		t.Fatal(err)
+	}
+	l.Audit("login")
+	l.SetShowDetail(true)
+	l.Audit("detail")
+	l.SetAuditLog(nil)
+
+	content, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(content), "existing\n1 ") || !strings.Contains(string(content), " login\n") {
+		t.Errorf("unexpected audit file %q", content)
+	}
+	if !strings.Contains(string(content), "audit_test.go:") {
+		t.Errorf("unexpected audit file %q", content)
+	}
+}
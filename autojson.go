@@ -0,0 +1,57 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// SetAutoFormatJSON makes the logger indent JSON objects and arrays found in
+// formatted messages, e.g. a request body passed as an argument. Lazy
+// processors see the indented text.
+func (l *Logger) SetAutoFormatJSON(b bool) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.AutoFormatJSON = b })
+}
+
+// indentJSON returns msg with every embedded JSON object or array indented
+// by two spaces. Messages without '{' or '[' are returned as they are,
+// without allocating.
+func indentJSON(msg string) string {
+	var out strings.Builder
+	rest := msg
+	for {
+		i := strings.IndexAny(rest, "{[")
+		if i < 0 {
+			break
+		}
+		n, indented := decodeJSONPrefix(rest[i:])
+		if n == 0 {
+			out.WriteString(rest[:i+1])
+			rest = rest[i+1:]
+			continue
+		}
+		out.WriteString(rest[:i])
+		out.Write(indented)
+		rest = rest[i+n:]
+	}
+	if out.Len() == 0 {
+		return msg
+	}
+	out.WriteString(rest)
+	return out.String()
+}
+
+// decodeJSONPrefix reads one JSON value from the start of s and returns its
+// length and indented form, or 0 if s does not start with valid JSON.
+func decodeJSONPrefix(s string) (int, []byte) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return 0, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return 0, nil
+	}
+	return int(dec.InputOffset()), buf.Bytes()
+}
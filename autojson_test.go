@@ -0,0 +1,28 @@
+package golog
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// TestAutoFormatJSON checks that JSON arguments are indented and other text is kept.
+func TestAutoFormatJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetAutoFormatJSON(true)
+	l.Info("request {id} body=%s done", `{"user":"bob","tags":["a"]}`)
+
+	expected := fmt.Sprintf("%s request {id} body={\n  \"user\": \"bob\",\n  \"tags\": [\n    \"a\"\n  ]\n} done \n", "[INFO]")
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+// TestIndentJSONNoAlloc checks that messages without JSON are not copied.
+func TestIndentJSONNoAlloc(t *testing.T) {
+	msg := "plain message with no brackets"
+	if n := testing.AllocsPerRun(100, func() { indentJSON(msg) }); n != 0 {
+		t.Errorf("expected no allocations, got %v", n)
+	}
+}
@@ -0,0 +1,8 @@
+package golog
+
+// SetAutoInjectBaggage makes the Ctx variants add each OpenTelemetry baggage
+// member in the context as a field. Baggage is only read in builds with the
+// otel tag; without it the setting has no effect.
+func (l *Logger) SetAutoInjectBaggage(b bool) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.AutoInjectBaggage = b })
+}
@@ -0,0 +1,22 @@
+//go:build otel
+
+package golog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// baggageFields returns the baggage members in ctx as fields.
+func baggageFields(ctx context.Context) []Field {
+	members := baggage.FromContext(ctx).Members()
+	if len(members) == 0 {
+		return nil
+	}
+	fields := make([]Field, len(members))
+	for i, m := range members {
+		fields[i] = Field{Key: m.Key(), Value: m.Value()}
+	}
+	return fields
+}
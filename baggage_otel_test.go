@@ -0,0 +1,27 @@
+//go:build otel
+
+package golog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// TestAutoInjectBaggage checks that baggage members become fields on Ctx messages.
+func TestAutoInjectBaggage(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetAutoInjectBaggage(true)
+
+	member, _ := baggage.NewMember("user_id", "42")
+	bag, _ := baggage.New(member)
+	l.InfoCtx(baggage.ContextWithBaggage(context.Background(), bag), "checkout")
+
+	expected := "[INFO] checkout user_id=42 \n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !otel
+
+package golog
+
+import "context"
+
+func baggageFields(ctx context.Context) []Field {
+	return nil
+}
@@ -0,0 +1,52 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+)
+
+// SetFileBanner writes a line each time a log file is opened, so restarts
+// are easy to spot. template is passed to fmt.Sprintf with the current time,
+// the main module version and the hostname, e.g.
+// "=== started %[1]s version=%[2]s host=%[3]s ===". An empty template
+// disables the banner.
+func (l *Logger) SetFileBanner(template string) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.bannerTemplate = template
+}
+
+// SetFileBannerFunc is like SetFileBanner but calls fn for the banner text,
+// for banners that need more than the template arguments. It takes
+// precedence over SetFileBanner.
+func (l *Logger) SetFileBannerFunc(fn func() string) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.bannerFunc = fn
+}
+
+// fileBanner returns the banner line to write, newline-terminated, or "".
+// The caller must hold logFileMutex.
+func (l *Logger) fileBanner() string {
+	var banner string
+	switch {
+	case l.bannerFunc != nil:
+		banner = l.bannerFunc()
+	case l.bannerTemplate != "":
+		hostname, _ := os.Hostname()
+		banner = fmt.Sprintf(l.bannerTemplate, l.now(), mainVersion(), hostname)
+	}
+	if banner != "" && !strings.HasSuffix(banner, Newline) {
+		banner += Newline
+	}
+	return banner
+}
+
+func mainVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Version
+	}
+	return "unknown"
+}
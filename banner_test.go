@@ -0,0 +1,50 @@
+package golog
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readFreshLogFile(t *testing.T, l *Logger, msg string) []string {
+	t.Helper()
+	path := "log/" + time.Now().Format("2006-01-02_15") + ".log"
+	os.Remove(path)
+	l.writeToFile(msg)
+	l.closeLogFile()
+	defer os.Remove(path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(string(content), "\n")
+}
+
+// TestFileBanner checks that the banner template is the first line of a new file.
+func TestFileBanner(t *testing.T) {
+	l := NewLogger()
+	l.SetFileBanner("=== started version=%[2]s host=%[3]s ===")
+
+	lines := readFreshLogFile(t, l, "[INFO] first entry\n")
+	hostname, _ := os.Hostname()
+	if !strings.HasPrefix(lines[0], "=== started version=") || !strings.HasSuffix(lines[0], "host="+hostname+" ===") {
+		t.Errorf("unexpected banner %q", lines[0])
+	}
+	if lines[1] != "[INFO] first entry" {
+		t.Errorf("expected entry after banner, got %q", lines[1])
+	}
+}
+
+// TestFileBannerFunc checks that the banner func overrides the template.
+func TestFileBannerFunc(t *testing.T) {
+	l := NewLogger()
+	l.SetFileBanner("template %v")
+	l.SetFileBannerFunc(func() string { return "custom banner" })
+
+	lines := readFreshLogFile(t, l, "[INFO] entry\n")
+	if lines[0] != "custom banner" {
+		t.Errorf("expected custom banner, got %q", lines[0])
+	}
+}
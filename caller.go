@@ -0,0 +1,126 @@
+package golog
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// SetShowModulePath makes caller locations include the package import path,
+// e.g. "github.com/org/repo/internal/db/main.go:12" instead of "main.go:12".
+// This disambiguates files with the same base name in monorepos.
+func (l *Logger) SetShowModulePath(b bool) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.ShowModulePath = b })
+}
+
+// SetCallerSkip makes the default logger skip skip extra stack frames when
+// locating the call site.
+func SetCallerSkip(skip int) {
+	defaultLogger.SetCallerSkip(skip)
+}
+
+// SetCallerSkip skips skip extra stack frames when locating the call site,
+// so logging helpers that wrap l report their callers' locations rather than
+// their own. Each wrapping function adds one frame.
+func (l *Logger) SetCallerSkip(skip int) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.CallerSkip = skip })
+}
+
+// FilterAction is what a caller or tag filter does with matching messages.
+type FilterAction int
+
+const (
+	// Drop discards messages from matching packages and keeps the rest.
+	Drop FilterAction = iota
+	// Allow keeps only messages from matching packages.
+	Allow
+)
+
+// SetCallerFilter drops or exclusively allows messages by the import path of
+// the calling package. A package matches an entry in packages when its path
+// equals it or starts with it followed by "/", so "github.com/vendor/lib"
+// covers its subpackages too. The caller is resolved even when showDetail is
+// off. Each call, like SetCallerFilterByModule and ExcludeVendor, replaces
+// the previous filter; nil packages with Drop removes it.
+func (l *Logger) SetCallerFilter(packages []string, action FilterAction) {
+	if len(packages) == 0 && action == Drop {
+		l.setCallerFilter(nil)
+		return
+	}
+	packages = append([]string(nil), packages...)
+	l.setCallerFilter(func(frame runtime.Frame) bool {
+		return matchesPackage(funcPackage(frame.Function), packages) == (action == Allow)
+	})
+}
+
+// SetCallerFilterByModule filters callers by module rather than package:
+// every package whose import path lies under modulePath matches. An empty
+// modulePath means the main module of the running binary, as reported by
+// debug.ReadBuildInfo, so Allow keeps only the application's own messages.
+// Import paths are used instead of file paths, so the result does not depend
+// on GOPATH layout or vendoring.
+func (l *Logger) SetCallerFilterByModule(modulePath string, action FilterAction) {
+	if modulePath == "" {
+		modulePath = mainModulePath()
+	}
+	l.SetCallerFilter([]string{modulePath}, action)
+}
+
+// ExcludeVendor drops messages from code under a vendor directory, judged by
+// either the caller's file path or its import path.
+func (l *Logger) ExcludeVendor() {
+	l.setCallerFilter(notVendored)
+}
+
+func notVendored(frame runtime.Frame) bool {
+	return !strings.Contains(frame.File, "/vendor/") && !strings.Contains(frame.Function, "/vendor/")
+}
+
+// mainModulePath returns the main module's path, or "" when the binary was
+// built without module information.
+func mainModulePath() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path
+	}
+	return ""
+}
+
+func (l *Logger) setCallerFilter(filter func(runtime.Frame) bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.callerFilter = filter
+}
+
+func (l *Logger) callerFilterFunc() func(runtime.Frame) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.callerFilter
+}
+
+// callerAllowed reports whether a message from frame passes the caller filter.
+func (l *Logger) callerAllowed(frame runtime.Frame) bool {
+	filter := l.callerFilterFunc()
+	return filter == nil || filter(frame)
+}
+
+func matchesPackage(pkg string, packages []string) bool {
+	for _, p := range packages {
+		if p == "" {
+			continue
+		}
+		if pkg == p || strings.HasPrefix(pkg, strings.TrimSuffix(p, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// funcPackage parses the package import path out of a function name such as
+// "github.com/org/repo/pkg.(*T).Method".
+func funcPackage(name string) string {
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return name
+}
@@ -0,0 +1,102 @@
+package golog
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestShowModulePath checks that the caller location carries the full package path.
+func TestShowModulePath(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger()
+	l.w = &buf
+	l.SetShowDetail(true)
+	l.SetShowModulePath(true)
+	l.Info("with module path")
+
+	if !strings.Contains(buf.String(), " github.com/ryqdev/golog/caller_test.go:") {
+		t.Errorf("expected package path in caller, got %q", buf.String())
+	}
+}
+
+// TestCallerSkip checks that a wrapper one frame deep reports its caller.
+func TestCallerSkip(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetShowDetail(true)
+	l.SetCallerSkip(1)
+	logWrapped := func(msg string) { l.Info(msg) }
+
+	_, _, line, _ := runtime.Caller(0)
+	logWrapped("wrapped")
+	if want := fmt.Sprintf(" caller_test.go:%d ", line+1); !strings.Contains(buf.String(), want) {
+		t.Errorf("expected call site %q, got %q", want, buf.String())
+	}
+}
+
+// TestCallerFilter checks Drop and Allow against the calling test package.
+func TestCallerFilter(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+
+	l.SetCallerFilter([]string{"github.com/ryqdev/golog"}, Drop)
+	l.Info("from the package under test")
+	if buf.Len() != 0 {
+		t.Errorf("expected message from filtered package to be dropped, got %q", buf.String())
+	}
+
+	l.SetCallerFilter([]string{"testing"}, Allow)
+	l.Info("not from testing")
+	if buf.Len() != 0 {
+		t.Errorf("expected only testing package callers to pass, got %q", buf.String())
+	}
+
+	l.SetCallerFilter([]string{"github.com/ryqdev"}, Allow)
+	l.Info("kept")
+	if got, want := buf.String(), "[INFO] kept \n"; got != want {
+		t.Errorf("expected %q without caller detail, got %q", want, got)
+	}
+}
+
+// TestMatchesPackage checks that prefixes only match on path boundaries.
+func TestMatchesPackage(t *testing.T) {
+	for _, tc := range []struct {
+		pkg  string
+		want bool
+	}{
+		{"github.com/vendor/lib", true},
+		{"github.com/vendor/lib/sub", true},
+		{"github.com/vendor/library", false},
+		{"main", false},
+	} {
+		if got := matchesPackage(tc.pkg, []string{"github.com/vendor/lib"}); got != tc.want {
+			t.Errorf("matchesPackage(%q) = %v, want %v", tc.pkg, got, tc.want)
+		}
+	}
+}
+
+// TestCallerFilterByModule checks module matching and vendor exclusion against synthetic frames.
+func TestCallerFilterByModule(t *testing.T) {
+	app := runtime.Frame{Function: "example.com/app/internal/db.Open", File: "/src/app/internal/db/db.go"}
+	dep := runtime.Frame{Function: "example.com/lib.(*Client).Do", File: "/go/pkg/mod/example.com/lib@v1.2.0/client.go"}
+	vendored := runtime.Frame{Function: "example.com/lib.(*Client).Do", File: "/src/app/vendor/example.com/lib/client.go"}
+
+	l := NewLogger()
+	l.SetCallerFilterByModule("example.com/app", Allow)
+	if filter := l.callerFilterFunc(); !filter(app) || filter(dep) {
+		t.Error("expected only the app module to pass an Allow filter")
+	}
+
+	l.SetCallerFilterByModule("example.com/lib", Drop)
+	if filter := l.callerFilterFunc(); !filter(app) || filter(dep) {
+		t.Error("expected the dependency to be dropped")
+	}
+
+	l.ExcludeVendor()
+	if filter := l.callerFilterFunc(); !filter(app) || !filter(dep) || filter(vendored) {
+		t.Error("expected only the vendored frame to be dropped")
+	}
+}
@@ -0,0 +1,38 @@
+package golog
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// ansiSequence matches the SGR escape sequences golog colors output with.
+var ansiSequence = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// CaptureOutput runs fn with l's console output going to a buffer and
+// returns what was written, with color codes stripped. Messages logged by
+// other goroutines while fn runs are captured too, as are those of child
+// loggers derived inside fn; children derived earlier keep their writer. The
+// console writer is restored afterwards, even if fn panics.
+func CaptureOutput(l *Logger, fn func()) string {
+	var buf bytes.Buffer
+	l.mutex.Lock()
+	prev := l.w
+	l.w = &buf
+	l.mutex.Unlock()
+	defer func() {
+		l.mutex.Lock()
+		l.w = prev
+		l.mutex.Unlock()
+	}()
+
+	fn()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return ansiSequence.ReplaceAllString(buf.String(), "")
+}
+
+// CaptureDefaultOutput is CaptureOutput for the default logger.
+func CaptureDefaultOutput(fn func()) string {
+	return CaptureOutput(defaultLogger, fn)
+}
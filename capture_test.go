@@ -0,0 +1,27 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestCaptureOutput checks that output is captured without color codes and
+// the original writer is restored.
+func TestCaptureOutput(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetColorEnabled(true)
+
+	out := CaptureOutput(l, func() {
+		l.Info("captured")
+		l.Named("db").Warn("slow query")
+	})
+	if want := "[INFO] captured \n[WARN] [db] slow query \n"; out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+
+	l.Info("after")
+	if buf.String() != InfoLevel+" after \n" {
+		t.Errorf("expected the original writer back, got %q", buf.String())
+	}
+}
@@ -0,0 +1,32 @@
+package golog
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// noCeiling disables the global level ceiling.
+const noCeiling = math.MaxInt32
+
+var levelCeiling int32 = noCeiling
+
+// SetGlobalLevelCeiling caps the level of every message from every logger at
+// maxLevel: a message above it is logged as if it were at maxLevel. Unlike
+// SetLevel this redirects rather than suppresses, which lets test suites keep
+// severe messages visible while making sure they behave like ordinary ones.
+func SetGlobalLevelCeiling(maxLevel Level) {
+	atomic.StoreInt32(&levelCeiling, int32(maxLevel))
+}
+
+// ClearGlobalLevelCeiling removes the ceiling set by SetGlobalLevelCeiling.
+func ClearGlobalLevelCeiling() {
+	atomic.StoreInt32(&levelCeiling, noCeiling)
+}
+
+// capLevel applies the global ceiling to level.
+func capLevel(level Level) Level {
+	if ceiling := Level(atomic.LoadInt32(&levelCeiling)); level > ceiling {
+		return ceiling
+	}
+	return level
+}
@@ -0,0 +1,62 @@
+package golog
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestGlobalLevelCeiling checks that messages above the ceiling are downgraded, not dropped.
+func TestGlobalLevelCeiling(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	SetGlobalLevelCeiling(LevelInfo)
+	defer ClearGlobalLevelCeiling()
+
+	l.Error("disk full")
+	l.Debug("still filtered")
+	if got, want := buf.String(), "[INFO] disk full \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if n := l.Stats().ByLevel[LevelError]; n != 0 {
+		t.Errorf("expected no messages counted at Error, got %d", n)
+	}
+}
+
+// TestCeilingSuppressesFatalExit checks that a capped Fatal logs at the
+// ceiling and does not end the process.
+func TestCeilingSuppressesFatalExit(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	SetGlobalLevelCeiling(LevelError)
+	defer ClearGlobalLevelCeiling()
+	exited := false
+	exitFunc = func(int) { exited = true }
+	defer func() { exitFunc = os.Exit }()
+
+	l.Fatal("cannot continue")
+	if exited {
+		t.Error("expected Fatal not to exit under the ceiling")
+	}
+	if got, want := buf.String(), "[ERROR] cannot continue \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+// TestCeilingSuppressesPanic checks that a capped Panic does not panic.
+func TestCeilingSuppressesPanic(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	SetGlobalLevelCeiling(LevelError)
+	defer ClearGlobalLevelCeiling()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("expected no panic under the ceiling, got %v", r)
+		}
+	}()
+
+	l.Panic("disk %d failed", 3)
+	if got, want := buf.String(), "[ERROR] disk 3 failed \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
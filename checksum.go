@@ -0,0 +1,126 @@
+package golog
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumAlgo selects the checksum written next to rotated log files.
+type ChecksumAlgo int
+
+const (
+	ChecksumNone ChecksumAlgo = iota
+	ChecksumSHA256
+	ChecksumMD5
+)
+
+// checksumAlgos lists the algorithms VerifyChecksum looks for, in order.
+var checksumAlgos = []ChecksumAlgo{ChecksumSHA256, ChecksumMD5}
+
+// ext returns the sidecar file extension for algo.
+func (algo ChecksumAlgo) ext() string {
+	switch algo {
+	case ChecksumSHA256:
+		return ".sha256"
+	case ChecksumMD5:
+		return ".md5"
+	}
+	return ""
+}
+
+func (algo ChecksumAlgo) newHash() hash.Hash {
+	if algo == ChecksumMD5 {
+		return md5.New()
+	}
+	return sha256.New()
+}
+
+// SetChecksumOnRotate writes a checksum of each log file rotated out, by
+// time or size, to a sidecar named after it, e.g. 2006-01-02_15.log.sha256.
+// The sidecar uses the sha256sum/md5sum format, so either those tools or
+// VerifyChecksum can check it. The checksum is computed off the writer's
+// path, before the rotation callback runs. ChecksumNone turns it off.
+func (l *Logger) SetChecksumOnRotate(algo ChecksumAlgo) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.checksumAlgo = algo
+}
+
+// rotated writes the checksum of the file rotated out to path, if enabled,
+// and then calls the rotation callback, both in their own goroutine. The
+// caller must hold logFileMutex.
+func (l *Logger) rotated(path string) {
+	algo, cb := l.checksumAlgo, l.rotationCb
+	if algo == ChecksumNone && cb == nil {
+		return
+	}
+	go func() {
+		if algo != ChecksumNone {
+			if err := writeChecksum(path, algo); err != nil {
+				l.reportError(fmt.Errorf("golog: checksum rotated file: %w", err))
+			}
+		}
+		if cb != nil {
+			cb(path)
+		}
+	}()
+}
+
+func writeChecksum(path string, algo ChecksumAlgo) error {
+	sum, err := fileChecksum(path, algo)
+	if err != nil {
+		return err
+	}
+	line := sum + "  " + filepath.Base(path) + "\n"
+	return os.WriteFile(path+algo.ext(), []byte(line), 0644)
+}
+
+func fileChecksum(path string, algo ChecksumAlgo) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	h := algo.newHash()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ErrChecksumMismatch is returned by VerifyChecksum when a file no longer
+// matches its sidecar.
+var ErrChecksumMismatch = errors.New("golog: checksum mismatch")
+
+// VerifyChecksum recomputes the checksum of the log file at path and
+// compares it with the sidecar SetChecksumOnRotate wrote, trying .sha256
+// before .md5.
+func VerifyChecksum(path string) error {
+	for _, algo := range checksumAlgos {
+		content, err := os.ReadFile(path + algo.ext())
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		want, _, _ := strings.Cut(strings.TrimSpace(string(content)), " ")
+		got, err := fileChecksum(path, algo)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(got, want) {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, path)
+		}
+		return nil
+	}
+	return fmt.Errorf("golog: no checksum file for %s", path)
+}
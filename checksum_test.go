@@ -0,0 +1,53 @@
+package golog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestChecksumOnRotate checks that a rotated file gets a sidecar that
+// verifies, and that tampering is detected.
+func TestChecksumOnRotate(t *testing.T) {
+	for _, algo := range []ChecksumAlgo{ChecksumSHA256, ChecksumMD5} {
+		l := NewLogger()
+		l.SetLogDir(t.TempDir())
+		l.SetChecksumOnRotate(algo)
+		rotated := make(chan string, 1)
+		l.SetRotationCallback(func(path string) { rotated <- path })
+
+		l.writeToFile("[INFO] first\n")
+		l.currentHour = "stale"
+		l.writeToFile("[INFO] second\n")
+		l.closeLogFile()
+
+		var path string
+		select {
+		case path = <-rotated:
+		case <-time.After(time.Second):
+			t.Fatal("rotation callback was not called")
+		}
+		if _, err := os.Stat(path + algo.ext()); err != nil {
+			t.Fatalf("expected sidecar next to %s: %v", filepath.Base(path), err)
+		}
+		if err := VerifyChecksum(path); err != nil {
+			t.Errorf("expected %s to verify, got %v", path, err)
+		}
+
+		os.WriteFile(path, []byte("[INFO] forged\n"), 0644)
+		if err := VerifyChecksum(path); !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("expected a mismatch after tampering, got %v", err)
+		}
+	}
+}
+
+// TestVerifyChecksumMissingSidecar checks that a file without a sidecar fails.
+func TestVerifyChecksumMissingSidecar(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	os.WriteFile(path, []byte("[INFO] msg\n"), 0644)
+	if err := VerifyChecksum(path); err == nil {
+		t.Error("expected an error without a sidecar")
+	}
+}
@@ -0,0 +1,155 @@
+package golog
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// fileState is shared by a logger and every clone, so that all of them
+// follow StartWithContext, Close and DisableLogFile called on any one.
+type fileState struct {
+	enabled atomic.Bool // Whether messages go to the file channel
+
+	mutex sync.Mutex
+	done  chan struct{} // Closed when the current file writer exits, nil before one starts
+}
+
+func (s *fileState) writerDone() chan struct{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.done
+}
+
+func (s *fileState) setWriterDone(done chan struct{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.done = done
+}
+
+// setWriteLogToFile turns sending messages to the file channel on or off
+// for l and every logger sharing its file channel.
+func (l *Logger) setWriteLogToFile(b bool) {
+	l.files.enabled.Store(b)
+}
+
+// Close stops file logging on the default logger. See Logger.Close.
+func Close() error {
+	return defaultLogger.Close()
+}
+
+// Close stops file logging for l and the loggers derived from it: it waits
+// for the file writer to write everything already queued, then syncs and
+// closes the log file. It returns the first error met writing the file
+// since it was enabled. Console output continues, and SetLogFile may enable
+// the file again afterwards. Concurrent Close calls must not race.
+func (l *Logger) Close() error {
+	l.setWriteLogToFile(false)
+	if done := l.files.writerDone(); done != nil && !isDone(done) {
+		// The channel is shared with clones and stays open; the writer
+		// exits when it reaches the stop request.
+		select {
+		case l.logChannel <- fileMsg{stop: true}:
+			<-done
+		case <-done:
+		}
+	}
+
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	err := l.fileErr
+	l.fileErr = nil
+	if l.logFile != nil {
+		if gzErr := l.closeGzip(); err == nil {
+			err = gzErr
+		}
+		if syncErr := l.logFile.Sync(); err == nil {
+			err = syncErr
+		}
+		l.closeLogFile()
+		l.logFile = nil
+	}
+	return err
+}
+
+// enableLogFile starts the file writer goroutine.
+func (l *Logger) enableLogFile() {
+	l.StartWithContext(context.Background())
+}
+
+// DefaultDrainTimeout bounds the drain after the context passed to
+// StartWithContext is done, when no timeout has been set.
+const DefaultDrainTimeout = 5 * time.Second
+
+// StartWithContext enables the log file with a file writer that stops when
+// ctx is done, e.g. one from signal.NotifyContext. The writer then writes
+// the messages already queued, until the drain timeout passes, and syncs
+// and closes the log file. Later messages from l and its children are no
+// longer written to the file, and never block. Close still works
+// afterwards.
+func (l *Logger) StartWithContext(ctx context.Context) {
+	done := make(chan struct{})
+	l.files.setWriterDone(done)
+	l.setWriteLogToFile(true)
+	go func() {
+		defer close(done)
+		l.startFileWriter(ctx)
+	}()
+}
+
+// SetDrainTimeout bounds how long the file writer keeps writing queued
+// messages once the context given to StartWithContext is done. Zero
+// restores DefaultDrainTimeout.
+func (l *Logger) SetDrainTimeout(d time.Duration) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.DrainTimeout = d })
+}
+
+// drainAndClose writes the messages already queued, until the channel is
+// empty or the drain timeout passes, and then closes the log file. Messages
+// sent afterwards are dropped by sendFile once the writer has exited.
+func (l *Logger) drainAndClose() {
+	timeout := l.settings().DrainTimeout
+	if timeout <= 0 {
+		timeout = DefaultDrainTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+drain:
+	for {
+		select {
+		case msg, ok := <-l.logChannel:
+			if !ok || msg.stop {
+				break drain
+			}
+			l.handleFileMsg(msg)
+		case <-timer.C:
+			break drain
+		default:
+			break drain
+		}
+	}
+
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	if l.logFile != nil {
+		if err := l.closeGzip(); err != nil && l.fileErr == nil {
+			l.fileErr = err
+		}
+		if err := l.logFile.Sync(); err != nil && l.fileErr == nil {
+			l.fileErr = err
+		}
+		l.closeLogFile()
+		l.logFile = nil
+	}
+}
+
+// isDone reports whether done has been closed.
+func isDone(done chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
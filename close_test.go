@@ -0,0 +1,103 @@
+package golog
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestClose checks that messages still queued when Close is called reach the file.
+func TestClose(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+	l.SetLogDir(t.TempDir())
+	l.enableLogFile()
+	for i := 0; i < logChannelSize; i++ {
+		l.Info("message %d", i)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(l.logFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(content), "\n"); n != logChannelSize {
+		t.Errorf("expected %d lines, got %d", logChannelSize, n)
+	}
+
+	// The file can be enabled again after Close.
+	l.enableLogFile()
+	l.Info("reopened")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	content, _ = os.ReadFile(l.logFilePath)
+	if !strings.HasSuffix(string(content), "reopened \n") {
+		t.Errorf("expected the new message after reopening, got %q", content)
+	}
+}
+
+// TestStartWithContext checks that cancelling the context drains queued
+// messages, closes the file and stops further sends.
+func TestStartWithContext(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+	l.SetLogDir(t.TempDir())
+	l.setWriteLogToFile(true)
+	for i := 0; i < 10; i++ {
+		l.Info("queued %d", i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l.StartWithContext(ctx)
+	<-l.files.writerDone()
+
+	content, err := os.ReadFile(l.logFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(content), "\n"); n != 10 {
+		t.Errorf("expected 10 drained lines, got %d", n)
+	}
+	if l.logFile != nil {
+		t.Error("expected the log file to be closed")
+	}
+	child := l.Named("worker")
+	for i := 0; i <= cap(l.logChannel); i++ {
+		child.Info("after shutdown") // Would block once the channel filled up
+	}
+	if err := l.Flush(); err != nil {
+		t.Errorf("expected Flush to return at once after shutdown, got %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestCloseWithChildren checks that children made before Close stop writing
+// to the file with their parent instead of sending on a dead channel, and
+// follow it when the file is enabled again.
+func TestCloseWithChildren(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+	l.SetLogDir(t.TempDir())
+	child := l.WithField("k", 1)
+	l.enableLogFile()
+	child.Info("before close")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	child.Info("after close") // Used to panic: send on closed channel
+
+	l.enableLogFile()
+	child.Info("reopened")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(l.logFilePath)
+	if got := string(content); !strings.Contains(got, "before close") || strings.Contains(got, "after close") || !strings.Contains(got, "reopened") {
+		t.Errorf("unexpected file content %q", got)
+	}
+}
@@ -0,0 +1,102 @@
+// Package cloudlog adapts a golog Logger to the serverless or cloud runtime
+// it is running on.
+package cloudlog
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+// Environment identifies the execution environment detected at startup.
+type Environment int
+
+const (
+	Local Environment = iota
+	CloudRun
+	Lambda
+	GCE
+)
+
+func (e Environment) String() string {
+	switch e {
+	case CloudRun:
+		return "cloudrun"
+	case Lambda:
+		return "lambda"
+	case GCE:
+		return "gce"
+	}
+	return "local"
+}
+
+// metadataURL is the GCE metadata server root; it answers with a
+// "Metadata-Flavor: Google" header.
+var metadataURL = "http://metadata.google.internal/"
+
+// metadataTimeout bounds the GCE probe when nothing answers.
+const metadataTimeout = 300 * time.Millisecond
+
+// ProbeGCE enables GCE detection. It is off by default because recognizing
+// GCE takes a request to the metadata server, which outside GCE delays
+// startup by up to 300ms until it times out.
+var ProbeGCE = false
+
+// DetectEnvironment reports where the process is running. Cloud Run and
+// Lambda are recognized from their environment variables; GCE, only when
+// ProbeGCE is set, from a request to the metadata server.
+func DetectEnvironment() Environment {
+	switch {
+	case os.Getenv("K_SERVICE") != "":
+		return CloudRun
+	case os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "":
+		return Lambda
+	case ProbeGCE && onGCE():
+		return GCE
+	}
+	return Local
+}
+
+func onGCE() bool {
+	client := http.Client{Timeout: metadataTimeout}
+	req, err := http.NewRequest(http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.Header.Get("Metadata-Flavor") == "Google"
+}
+
+// AutoConfigureForCloud detects the environment and configures l for it and
+// returns what was detected. In the cloud, console output and the log file
+// are written as JSON lines and a service field is attached when the
+// platform names one. On Lambda the log file is disabled because the
+// filesystem is ephemeral. Locally l is left as it is.
+func AutoConfigureForCloud(l *golog.Logger) Environment {
+	env := DetectEnvironment()
+	if env == Local {
+		return env
+	}
+	l.SetFormatter(golog.JSONFormatter{})
+	l.SetFileFormat(golog.FileFormatJSONL)
+
+	var service string
+	switch env {
+	case CloudRun:
+		service = os.Getenv("K_SERVICE")
+	case Lambda:
+		service = os.Getenv("AWS_LAMBDA_FUNCTION_NAME")
+		l.DisableLogFile()
+	}
+	if service != "" {
+		l.AddFields(golog.Field{Key: "service", Value: service})
+	}
+	return env
+}
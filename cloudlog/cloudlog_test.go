@@ -0,0 +1,112 @@
+package cloudlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ryqdev/golog"
+)
+
+func clearEnv(t *testing.T) {
+	t.Setenv("K_SERVICE", "")
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "")
+	old, oldProbe := metadataURL, ProbeGCE
+	metadataURL = "http://127.0.0.1:1/"
+	t.Cleanup(func() { metadataURL, ProbeGCE = old, oldProbe })
+}
+
+// gceServer starts a fake metadata server and reports whether it was asked.
+func gceServer(t *testing.T) *bool {
+	asked := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		asked = true
+		if r.Header.Get("Metadata-Flavor") == "Google" {
+			w.Header().Set("Metadata-Flavor", "Google")
+		}
+	}))
+	t.Cleanup(srv.Close)
+	metadataURL = srv.URL
+	return &asked
+}
+
+// TestCloudRun checks that Cloud Run is detected and the service name attached.
+func TestCloudRun(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("K_SERVICE", "checkout")
+
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf))
+	if env := AutoConfigureForCloud(l); env != CloudRun {
+		t.Fatalf("expected cloudrun, got %v", env)
+	}
+	l.Info("started")
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("expected a JSON line, got %q", buf.String())
+	}
+	if got["level"] != "INFO" || got["msg"] != "started" || got["service"] != "checkout" {
+		t.Errorf("unexpected entry %v", got)
+	}
+}
+
+// TestLambda checks that Lambda takes precedence over GCE and names the service.
+func TestLambda(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "resize")
+
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf))
+	if env := AutoConfigureForCloud(l); env != Lambda {
+		t.Fatalf("expected lambda, got %v", env)
+	}
+	l.Info("invoked")
+	if !strings.Contains(buf.String(), `"service":"resize"`) {
+		t.Errorf("expected service field, got %q", buf.String())
+	}
+}
+
+// TestGCE checks detection through the metadata server header.
+func TestGCE(t *testing.T) {
+	clearEnv(t)
+	gceServer(t)
+	ProbeGCE = true
+
+	if env := DetectEnvironment(); env != GCE {
+		t.Errorf("expected gce, got %v", env)
+	}
+}
+
+// TestGCEProbeOptIn checks that the metadata server is not contacted unless
+// ProbeGCE is set.
+func TestGCEProbeOptIn(t *testing.T) {
+	clearEnv(t)
+	asked := gceServer(t)
+
+	if env := DetectEnvironment(); env != Local {
+		t.Errorf("expected local, got %v", env)
+	}
+	if *asked {
+		t.Error("expected no metadata request without ProbeGCE")
+	}
+}
+
+// TestLocal checks that the logger is untouched outside the cloud.
+func TestLocal(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf))
+	if env := AutoConfigureForCloud(l); env != Local {
+		t.Fatalf("expected local, got %v", env)
+	}
+	l.Info("hi")
+	if got, want := buf.String(), "[INFO] hi \n"; got != want {
+		t.Errorf("expected colored output %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,85 @@
+//go:build !windows
+
+// Command gologrotate is a logrotate postrotate helper for programs using
+// golog's Logger.ReopenOnSignal. It sends SIGHUP to the process whose PID is
+// in the pid file and waits for the log file to reappear:
+//
+//	postrotate
+//	    /usr/local/bin/gologrotate -pidfile /run/myapp.pid /var/log/myapp/current.log
+//	endscript
+//
+// It exits 0 once the new file exists and 1 on any failure.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const pollInterval = 50 * time.Millisecond
+
+func main() {
+	pidFile := flag.String("pidfile", "/run/golog.pid", "file containing the PID of the logging process")
+	timeout := flag.Duration("timeout", 10*time.Second, "how long to wait for the new log file")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: gologrotate [-pidfile path] [-timeout d] logfile\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if err := run(*pidFile, flag.Arg(0), *timeout); err != nil {
+		fmt.Fprintln(os.Stderr, "gologrotate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(pidFile, logFile string, timeout time.Duration) error {
+	pid, err := readPID(pidFile)
+	if err != nil {
+		return err
+	}
+	if err := syscall.Kill(pid, syscall.SIGHUP); err != nil {
+		return fmt.Errorf("signal process %d: %w", pid, err)
+	}
+	return waitForFile(logFile, timeout)
+}
+
+func readPID(path string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("%s: invalid pid %q", path, strings.TrimSpace(string(content)))
+	}
+	return pid, nil
+}
+
+// waitForFile polls until path exists or timeout elapses.
+func waitForFile(path string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		_, err := os.Stat(path)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s did not reappear within %v", path, timeout)
+		}
+		time.Sleep(pollInterval)
+	}
+}
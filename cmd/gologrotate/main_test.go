@@ -0,0 +1,47 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestRun checks that the process is signalled and the recreated file is awaited.
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "app.pid")
+	logFile := filepath.Join(dir, "app.log")
+	os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644)
+
+	// Stand in for a logger reopening its file on SIGHUP.
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	go func() {
+		<-hup
+		os.WriteFile(logFile, nil, 0644)
+	}()
+
+	if err := run(pidFile, logFile, 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRunFailures checks a bad pid file and a file that never reappears.
+func TestRunFailures(t *testing.T) {
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "app.pid")
+	os.WriteFile(pidFile, []byte("not-a-pid"), 0644)
+	if err := run(pidFile, filepath.Join(dir, "app.log"), time.Second); err == nil {
+		t.Error("expected an error for an invalid pid")
+	}
+	if err := waitForFile(filepath.Join(dir, "missing.log"), 100*time.Millisecond); err == nil {
+		t.Error("expected a timeout for a file that never appears")
+	}
+}
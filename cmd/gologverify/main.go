@@ -0,0 +1,32 @@
+// Command gologverify checks a rotated log file against the checksum
+// sidecar written by golog's Logger.SetChecksumOnRotate:
+//
+//	gologverify /var/log/myapp/2006-01-02_15.log
+//
+// It exits 0 if the file matches and 1 if it does not or cannot be checked.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ryqdev/golog"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: gologverify logfile\n")
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if err := golog.VerifyChecksum(flag.Arg(0)); err != nil {
+		fmt.Fprintln(os.Stderr, "gologverify:", err)
+		os.Exit(1)
+	}
+	fmt.Println(flag.Arg(0) + ": OK")
+}
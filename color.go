@@ -0,0 +1,52 @@
+package golog
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// SetColorEnabled turns level tag and keyword colors on or off for the
+// default logger, overriding terminal detection.
+func SetColorEnabled(enabled bool) {
+	defaultLogger.SetColorEnabled(enabled)
+}
+
+// SetColorEnabled turns level tag and keyword colors on or off, overriding
+// the default of coloring only console output that goes to a terminal and
+// is allowed by NO_COLOR and TERM.
+func (l *Logger) SetColorEnabled(enabled bool) {
+	l.Transact(func(cfg *LoggerConfig) {
+		cfg.ColorEnabled = enabled
+		cfg.ColorAuto = false
+	})
+}
+
+// colorOn reports whether console lines at level should be colored under cfg.
+func (l *Logger) colorOn(cfg *LoggerConfig, level Level) bool {
+	return cfg.ColorEnabled && (!cfg.ColorAuto || isTerminal(l.levelWriter(level)))
+}
+
+// terminals caches isTerminal per file, so detection costs one ioctl per
+// file rather than one per message.
+var terminals sync.Map // *os.File -> bool
+
+// isTerminal reports whether w, possibly wrapped by a safeWriter, is a
+// terminal.
+func isTerminal(w io.Writer) bool {
+	if sw, ok := w.(*safeWriter); ok {
+		w = sw.w
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	if tty, ok := terminals.Load(f); ok {
+		return tty.(bool)
+	}
+	tty := term.IsTerminal(int(f.Fd()))
+	terminals.Store(f, tty)
+	return tty
+}
@@ -0,0 +1,43 @@
+package golog
+
+import "strings"
+
+// gzipExt is appended to the names of log files written compressed.
+const gzipExt = ".gz"
+
+// SetCompression gzips log files, naming them with a .gz suffix, e.g.
+// 2006-01-02_15.log.gz. The file being written is left as it is; the change
+// takes effect when the next file is opened. Flush writes out what the
+// compressor has buffered, and the gzip stream is closed before each file
+// is, so rotated files are complete archives.
+func (l *Logger) SetCompression(enabled bool) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.compress = enabled
+}
+
+// flushGzip writes out the compressor's buffered data, if the current file
+// is compressed. The caller must hold logFileMutex.
+func (l *Logger) flushGzip() error {
+	if l.gzipWriter == nil {
+		return nil
+	}
+	return l.gzipWriter.Flush()
+}
+
+// closeGzip ends the gzip stream of the current file, if it is compressed,
+// writing its footer. The caller must hold logFileMutex.
+func (l *Logger) closeGzip() error {
+	if l.gzipWriter == nil {
+		return nil
+	}
+	err := l.gzipWriter.Close()
+	l.gzipWriter = nil
+	return err
+}
+
+// trimGzipExt returns name without a trailing .gz and whether it had one.
+func trimGzipExt(name string) (string, bool) {
+	trimmed := strings.TrimSuffix(name, gzipExt)
+	return trimmed, trimmed != name
+}
@@ -0,0 +1,76 @@
+package golog
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readGzip(t *testing.T, path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	return string(data)
+}
+
+// TestSetCompression checks that compression starts with the next file and
+// that rotated archives are complete.
+func TestSetCompression(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLogger()
+	l.SetLogDir(dir)
+	l.writeToFile("[INFO] plain\n")
+	plain := l.logFilePath
+
+	l.SetCompression(true)
+	l.writeToFile("[INFO] still plain\n")
+	if l.logFilePath != plain {
+		t.Fatalf("expected the open file to stay plain, got %s", l.logFilePath)
+	}
+
+	l.currentHour = "stale"
+	l.writeToFile("[INFO] compressed\n")
+	archive := l.logFilePath
+	if archive != plain+gzipExt {
+		t.Fatalf("expected %s, got %s", plain+gzipExt, archive)
+	}
+	if err := l.syncFile(); err != nil {
+		t.Fatal(err)
+	}
+	l.currentHour = "stale"
+	l.writeToFile("[INFO] next\n")
+	l.closeLogFile()
+
+	if got, _ := os.ReadFile(plain); string(got) != "[INFO] plain\n[INFO] still plain\n" {
+		t.Errorf("unexpected plain file %q", got)
+	}
+	if got := readGzip(t, archive); got != "[INFO] compressed\n[INFO] next\n" {
+		t.Errorf("unexpected archive contents %q", got)
+	}
+}
+
+// TestCompressionFileNames checks size rotation and retention with .gz names.
+func TestCompressionFileNames(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2020-01-01_01.log.gz")
+	if got := sequencePath(path); got != filepath.Join(dir, "2020-01-01_01.1.log.gz") {
+		t.Errorf("unexpected sequence path %s", got)
+	}
+	for _, name := range []string{"2020-01-01_01.log.gz", "2020-01-01_01.1.log.gz"} {
+		if !matchesFilePattern(name, DefaultFilePattern) {
+			t.Errorf("expected %s to match the file pattern", name)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package golog
+
+import "time"
+
+// LoggerConfig holds the settings that are read on every message. A logger
+// keeps its config in an immutable snapshot, so a message always sees one
+// consistent set of values even while Transact changes several at once.
+type LoggerConfig struct {
+	Level               Level
+	ShowDetail          bool
+	ShowModulePath      bool
+	NormalizeWhitespace bool
+	FileFormat          FileFormat
+	Theme               ColorTheme
+	ColorEnabled        bool
+	ColorAuto           bool      // Color only console writers that are terminals
+	Formatter           Formatter // nil means TextFormatter
+	AutoFormatJSON      bool
+	AutoInjectBaggage   bool
+	LevelOrder          []Level // nil means numeric order
+	CallerSkip          int     // Extra frames skipped to find the call site
+	PanicSafeProcessors bool
+	TimeFormat          string                  // Layout of the detail timestamp, DefaultTimeFormat if empty
+	TimeZone            *time.Location          // Zone of timestamps and file names, nil means time.Local
+	ErrorHandler        func(error)             // Receives internal errors, nil means stderr
+	FlushTimeout        time.Duration           // Bound on Flush, DefaultFlushTimeout if zero
+	DrainTimeout        time.Duration           // Bound on StartWithContext's drain, DefaultDrainTimeout if zero
+	HashFunc            func(msg string) uint64 // Keys deduplication and message sampling, nil for the defaults
+}
+
+// Transact calls fn with a copy of l's config and then publishes the result
+// in a single atomic swap, so concurrent messages observe either all of fn's
+// changes or none of them. Concurrent Transact calls and Set* methods are
+// serialized. fn must not call methods of l.
+func (l *Logger) Transact(fn func(cfg *LoggerConfig)) {
+	l.configMutex.Lock()
+	defer l.configMutex.Unlock()
+	cfg := *l.config.Load()
+	fn(&cfg)
+	l.config.Store(&cfg)
+}
+
+// Config returns a copy of l's current config.
+func (l *Logger) Config() LoggerConfig {
+	return *l.settings()
+}
+
+// settings returns the current config snapshot, which must not be modified.
+func (l *Logger) settings() *LoggerConfig {
+	return l.config.Load()
+}
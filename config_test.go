@@ -0,0 +1,66 @@
+package golog
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+)
+
+// TestTransact checks that readers never observe a partially applied update.
+// Run with -race to check the swap is free of data races.
+func TestTransact(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			// Level and ShowDetail are always changed together below.
+			if cfg := l.Config(); (cfg.Level == LevelDebug) != cfg.ShowDetail {
+				t.Errorf("observed inconsistent config %+v", cfg)
+				return
+			}
+			l.Info("concurrent")
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		debug := i%2 == 0
+		l.Transact(func(cfg *LoggerConfig) {
+			cfg.ShowDetail = debug
+			if debug {
+				cfg.Level = LevelDebug
+			} else {
+				cfg.Level = LevelInfo
+			}
+		})
+	}
+	close(stop)
+	wg.Wait()
+}
+
+// TestMessageUsesOneConfig checks that a message is rendered with the config
+// it was assembled under, even if Transact runs while it is in flight.
+func TestMessageUsesOneConfig(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.AddLazyProcessor(func(msg *string) {
+		l.Transact(func(cfg *LoggerConfig) {
+			cfg.Formatter = JSONFormatter{}
+			cfg.Level = LevelError
+		})
+	})
+
+	l.Info("ready")
+	if got, want := buf.String(), "[INFO] ready \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
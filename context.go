@@ -0,0 +1,46 @@
+package golog
+
+import "context"
+
+type contextKey struct {
+	key   any
+	field string
+}
+
+func WithContext(ctx context.Context) *Logger {
+	return defaultLogger.WithContext(ctx)
+}
+
+func RegisterContextKey(ctxKey any, fieldName string) {
+	defaultLogger.RegisterContextKey(ctxKey, fieldName)
+}
+
+// RegisterContextKey makes WithContext copy the value stored in a context
+// under ctxKey into the fieldName field. Child loggers created afterwards
+// inherit the registration.
+func (l *Logger) RegisterContextKey(ctxKey any, fieldName string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	keys := make([]contextKey, len(l.contextKeys), len(l.contextKeys)+1)
+	copy(keys, l.contextKeys)
+	l.contextKeys = append(keys, contextKey{key: ctxKey, field: fieldName})
+}
+
+func (l *Logger) contextKeyList() []contextKey {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.contextKeys
+}
+
+// WithContext returns a child logger carrying a field for every registered
+// context key that ctx has a value for, such as a request or trace ID. Keys
+// missing from ctx are left out.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	fields := make(Fields)
+	for _, k := range l.contextKeyList() {
+		if v := ctx.Value(k.key); v != nil {
+			fields[k.field] = v
+		}
+	}
+	return l.WithFields(fields)
+}
@@ -0,0 +1,28 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+type requestIDKey struct{}
+
+// TestWithContext checks that registered context values become fields and absent ones are omitted.
+func TestWithContext(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.RegisterContextKey(requestIDKey{}, "request_id")
+	l.RegisterContextKey("user", "user")
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		return "[http] " + format, v
+	})
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "abc123")
+	l.WithContext(ctx).Info("handled")
+
+	expected := "[INFO] [http] handled request_id=abc123 \n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,73 @@
+package golog
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// The Ctx variants behave like their plain counterparts, except that when
+// the file channel is full they wait for room only until ctx is done. A
+// message abandoned this way is counted by DroppedByContextCancellation and
+// never reaches the log file; console output and sinks are unaffected.
+
+func DebugCtx(ctx context.Context, format string, v ...any) {
+	defaultLogger.logCtx(ctx, LevelDebug, format, v...)
+}
+
+func InfoCtx(ctx context.Context, format string, v ...any) {
+	defaultLogger.logCtx(ctx, LevelInfo, format, v...)
+}
+
+func WarnCtx(ctx context.Context, format string, v ...any) {
+	defaultLogger.logCtx(ctx, LevelWarn, format, v...)
+}
+
+func ErrorCtx(ctx context.Context, format string, v ...any) {
+	defaultLogger.logCtx(ctx, LevelError, format, v...)
+}
+
+func (l *Logger) DebugCtx(ctx context.Context, format string, v ...any) {
+	l.logCtx(ctx, LevelDebug, format, v...)
+}
+
+func (l *Logger) InfoCtx(ctx context.Context, format string, v ...any) {
+	l.logCtx(ctx, LevelInfo, format, v...)
+}
+
+func (l *Logger) WarnCtx(ctx context.Context, format string, v ...any) {
+	l.logCtx(ctx, LevelWarn, format, v...)
+}
+
+func (l *Logger) ErrorCtx(ctx context.Context, format string, v ...any) {
+	l.logCtx(ctx, LevelError, format, v...)
+}
+
+// DroppedByContextCancellation returns how many messages l and its children
+// abandoned because their context ended before the file channel had room.
+func (l *Logger) DroppedByContextCancellation() int64 {
+	return atomic.LoadInt64(&l.stats.ctxDropped)
+}
+
+// sendFile queues fm for the file writer, giving up when ctx is done first.
+// Once the writer has shut down, fm is dropped rather than blocking.
+func (l *Logger) sendFile(ctx context.Context, fm fileMsg) {
+	done := l.files.writerDone()
+	select {
+	case l.logChannel <- fm:
+		return
+	default:
+	}
+	select {
+	case l.logChannel <- fm:
+	case <-done:
+		if fm.journal != nil {
+			fm.journal.consume(fm.seq, fm.line)
+		}
+	case <-ctx.Done():
+		atomic.AddInt64(&l.stats.ctxDropped, 1)
+		if fm.journal != nil {
+			fm.journal.consume(fm.seq, fm.line)
+		}
+		l.reportError(ctx.Err())
+	}
+}
@@ -0,0 +1,38 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+// TestInfoCtxDeadline checks that a full file channel does not block past the deadline.
+func TestInfoCtxDeadline(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.setWriteLogToFile(true) // No file writer is started, so the channel fills up
+	for i := 0; i < cap(l.logChannel); i++ {
+		l.logChannel <- fileMsg{line: "queued\n"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		l.InfoCtx(ctx, "late")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("InfoCtx blocked past the context deadline")
+	}
+
+	if n := l.DroppedByContextCancellation(); n != 1 {
+		t.Errorf("expected 1 message dropped by cancellation, got %d", n)
+	}
+	if buf.Len() == 0 {
+		t.Error("expected the message on the console")
+	}
+}
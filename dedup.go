@@ -0,0 +1,109 @@
+package golog
+
+import (
+	"sync"
+	"time"
+)
+
+// Deduplicator suppresses identical messages logged by any of a set of
+// loggers within a time window. The first logger to emit a message wins;
+// copies from the same or other loggers are dropped until the window since
+// that first occurrence has passed.
+type Deduplicator struct {
+	window time.Duration
+	now    func() time.Time
+
+	mutex     sync.Mutex
+	firstSeen map[dedupKey]time.Time
+	lastSweep time.Time
+	stats     DeduplicatorStats
+}
+
+// DeduplicatorStats counts messages seen and dropped by a Deduplicator.
+type DeduplicatorStats struct {
+	TotalSeen    int64
+	TotalDropped int64
+}
+
+// dedupKey identifies duplicates: the same level and formatted message.
+type dedupKey struct {
+	level   Level
+	content string
+	hash    uint64 // Stands in for content under SetHashFunc
+}
+
+// NewCrossLoggerDeduplicator starts deduplicating messages across loggers.
+// It applies to loggers derived from them afterwards as well.
+func NewCrossLoggerDeduplicator(window time.Duration, loggers ...*Logger) *Deduplicator {
+	d := &Deduplicator{
+		window:    window,
+		now:       time.Now,
+		firstSeen: make(map[dedupKey]time.Time),
+	}
+	for _, l := range loggers {
+		l.addRecordFilter(d.allow)
+	}
+	return d
+}
+
+// Stats returns the counts so far.
+func (d *Deduplicator) Stats() DeduplicatorStats {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.stats
+}
+
+func (d *Deduplicator) allow(rec record) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	now := d.now()
+	d.sweep(now)
+	d.stats.TotalSeen++
+	key := dedupKey{level: rec.level, content: rec.content}
+	if first, ok := d.firstSeen[key]; ok && now.Sub(first) < d.window {
+		d.stats.TotalDropped++
+		return false
+	}
+	d.firstSeen[key] = now
+	return true
+}
+
+// sweep forgets messages whose window has passed, at most once per window,
+// so the map does not grow with every distinct message ever logged.
+func (d *Deduplicator) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < d.window {
+		return
+	}
+	for key, first := range d.firstSeen {
+		if now.Sub(first) >= d.window {
+			delete(d.firstSeen, key)
+		}
+	}
+	d.lastSweep = now
+}
+
+// addRecordFilter registers fn to veto assembled messages before they are
+// written anywhere.
+func (l *Logger) addRecordFilter(fn func(record) bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	filters := make([]func(record) bool, len(l.recordFilters), len(l.recordFilters)+1)
+	copy(filters, l.recordFilters)
+	l.recordFilters = append(filters, fn)
+}
+
+func (l *Logger) recordFilterList() []func(record) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.recordFilters
+}
+
+func (l *Logger) recordAllowed(rec record) bool {
+	for _, fn := range l.recordFilterList() {
+		if !fn(rec) {
+			return false
+		}
+	}
+	return true
+}
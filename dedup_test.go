@@ -0,0 +1,38 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestCrossLoggerDeduplicator checks that the first logger wins and copies are dropped within the window.
+func TestCrossLoggerDeduplicator(t *testing.T) {
+	var bufA, bufB bytes.Buffer
+	a := NewLogger(WithOutput(&bufA))
+	b := NewLogger(WithOutput(&bufB))
+	d := NewCrossLoggerDeduplicator(time.Minute, a, b)
+	clock := time.Date(2024, 9, 17, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return clock }
+
+	a.Error("db unreachable")
+	b.Error("db unreachable")
+	b.WithTypedFields(Field{"req", 1}).Error("db unreachable")
+	b.Info("db unreachable")
+
+	if got, want := bufA.String(), "[ERROR] db unreachable \n"; got != want {
+		t.Errorf("expected first logger to win with %q, got %q", want, got)
+	}
+	if got, want := bufB.String(), "[INFO] db unreachable \n"; got != want {
+		t.Errorf("expected only the differently-leveled copy in b, got %q", got)
+	}
+
+	clock = clock.Add(time.Minute)
+	b.Error("db unreachable")
+	if stats := d.Stats(); stats.TotalSeen != 5 || stats.TotalDropped != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if len(d.firstSeen) != 1 {
+		t.Errorf("expected expired entries to be swept, got %d", len(d.firstSeen))
+	}
+}
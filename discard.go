@@ -0,0 +1,13 @@
+package golog
+
+import "io"
+
+// NewDiscardLogger returns a logger that writes nothing: its console writer
+// is io.Discard and it has no file channel, so nothing is allocated for file
+// logging and no goroutine is ever started. Processors, hooks, sinks and
+// stats still run as on any logger, which makes it suitable for silencing a
+// subsystem under test or benchmarking processors in isolation. Children
+// derived from it discard their output too.
+func NewDiscardLogger() *Logger {
+	return newLogger(nil, WithOutput(io.Discard))
+}
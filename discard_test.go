@@ -0,0 +1,38 @@
+package golog
+
+import (
+	"runtime"
+	"testing"
+)
+
+// TestNewDiscardLogger checks that processors and hooks still run, without
+// a file channel and without starting goroutines.
+func TestNewDiscardLogger(t *testing.T) {
+	before := runtime.NumGoroutine()
+	l := NewDiscardLogger()
+	if l.logChannel != nil {
+		t.Error("expected no file channel")
+	}
+	processed := 0
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		processed++
+		return format, v
+	})
+	h := &recordingHook{levels: []Level{LevelInfo}}
+	l.AddHook(h)
+
+	l.Info("silenced")
+	l.WithField("k", "v").Info("child")
+	if processed != 2 || len(h.entries) != 2 {
+		t.Errorf("expected processors and hooks to run, got %d and %d", processed, len(h.entries))
+	}
+	if err := l.Flush(); err != nil {
+		t.Error(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Error(err)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("expected no new goroutines, went from %d to %d", before, n)
+	}
+}
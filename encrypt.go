@@ -0,0 +1,31 @@
+package golog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryqdev/golog/fieldcrypt"
+)
+
+// NewFieldEncryptionProcessor replaces the values of key=value pairs whose
+// key is in sensitiveKeys with their AES-GCM encryption under key. Use
+// fieldcrypt.DecryptLogField to recover a value offline.
+func NewFieldEncryptionProcessor(sensitiveKeys []string, key [32]byte) Processor {
+	pattern := fieldPattern(sensitiveKeys)
+
+	return func(format string, v ...any) (string, []any) {
+		if pattern == nil {
+			return format, v
+		}
+		msg := pattern.ReplaceAllStringFunc(fmt.Sprintf(format, v...), func(field string) string {
+			name, value, _ := strings.Cut(field, "=")
+			encrypted, err := fieldcrypt.EncryptLogField(value, key)
+			if err != nil {
+				// Never fall back to the plaintext.
+				return name + "=[ENCRYPTION FAILED]"
+			}
+			return name + "=" + encrypted
+		})
+		return escapeFormat(msg), nil
+	}
+}
@@ -0,0 +1,25 @@
+package golog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ryqdev/golog/fieldcrypt"
+)
+
+// TestFieldEncryptionProcessor checks that a sensitive value is encrypted and decrypts back.
+func TestFieldEncryptionProcessor(t *testing.T) {
+	key := [32]byte{42}
+	p := NewFieldEncryptionProcessor([]string{"ssn"}, key)
+	format, v := p("user=%s ssn=%s", "bob", "123-45-6789")
+	msg := fmt.Sprintf(format, v...)
+
+	if strings.Contains(msg, "123-45-6789") || !strings.HasPrefix(msg, "user=bob ssn=") {
+		t.Fatalf("expected ssn to be encrypted, got %q", msg)
+	}
+	plain, err := fieldcrypt.DecryptLogField(strings.TrimPrefix(msg, "user=bob ssn="), key)
+	if err != nil || plain != "123-45-6789" {
+		t.Errorf("expected round trip, got %q, %v", plain, err)
+	}
+}
@@ -0,0 +1,60 @@
+package golog
+
+import "time"
+
+// Entry is a fully assembled log message, after processors have run.
+type Entry struct {
+	Level   Level
+	Message string
+	Time    time.Time
+	File    string // Caller's file name, with its package path under SetShowModulePath; empty unless showDetail is on
+	Line    int
+	Fields  map[string]any
+}
+
+func InfoEntry(format string, v ...any) Entry {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	return defaultLogger.log(LevelInfo, format, v...)
+}
+
+func DebugEntry(format string, v ...any) Entry {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	return defaultLogger.log(LevelDebug, format, v...)
+}
+
+func ErrorEntry(format string, v ...any) Entry {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	return defaultLogger.log(LevelError, format, v...)
+}
+
+// InfoEntry is Info returning the message as written, for callers that
+// need the final text, e.g. to return it in an API response. If the
+// message is filtered out, the zero Entry is returned.
+func (l *Logger) InfoEntry(format string, v ...any) Entry {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	return l.log(LevelInfo, format, v...)
+}
+
+// DebugEntry is Debug returning the message as written. See InfoEntry.
+func (l *Logger) DebugEntry(format string, v ...any) Entry {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	return l.log(LevelDebug, format, v...)
+}
+
+// ErrorEntry is Error returning the message as written. See InfoEntry.
+func (l *Logger) ErrorEntry(format string, v ...any) Entry {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	return l.log(LevelError, format, v...)
+}
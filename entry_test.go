@@ -0,0 +1,31 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestInfoEntry checks that the returned entry matches what was written and
+// that filtered messages return the zero Entry.
+func TestInfoEntry(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf), WithShowDetail(true)).WithField("user", "bob")
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		return "[api] " + format, v
+	})
+
+	e := l.InfoEntry("served %d", 200)
+	if e.Level != LevelInfo || e.Message != "[api] served 200" || e.Fields["user"] != "bob" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if e.File != "entry_test.go" || e.Line == 0 || e.Time.IsZero() {
+		t.Errorf("expected the call site and time in %+v", e)
+	}
+	if buf.Len() == 0 {
+		t.Error("expected the message to be written as well")
+	}
+
+	if e := l.DebugEntry("hidden"); e.Message != "" || !e.Time.IsZero() {
+		t.Errorf("expected the zero Entry for a filtered message, got %+v", e)
+	}
+}
@@ -0,0 +1,30 @@
+package golog
+
+// SetErrorHandler routes the default logger's internal errors to fn. See
+// Logger.SetErrorHandler.
+func SetErrorHandler(fn func(err error)) {
+	defaultLogger.SetErrorHandler(fn)
+}
+
+// SetErrorHandler calls fn with errors the logger cannot return to a
+// caller: failures opening, writing, rotating or cleaning up log files,
+// journal and audit writes, hook errors and panicking processors. fn may
+// log them elsewhere, count them or fail a test, but must not log through
+// l itself. File write failures are reported once until Close returns
+// them. nil restores the default, which writes them to stderr.
+func (l *Logger) SetErrorHandler(fn func(err error)) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.ErrorHandler = fn })
+}
+
+// reportError passes err to the error handler.
+func (l *Logger) reportError(err error) {
+	handleError(l.settings(), err)
+}
+
+func handleError(cfg *LoggerConfig, err error) {
+	if cfg.ErrorHandler != nil {
+		cfg.ErrorHandler(err)
+		return
+	}
+	reportError(err)
+}
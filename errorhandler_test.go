@@ -0,0 +1,34 @@
+package golog
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSetErrorHandler checks that file and hook errors reach the handler.
+func TestSetErrorHandler(t *testing.T) {
+	// A directory below a regular file cannot be created, even as root.
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0444); err != nil {
+		t.Fatal(err)
+	}
+	var errs []error
+	l := NewLogger(WithOutput(io.Discard))
+	l.SetErrorHandler(func(err error) { errs = append(errs, err) })
+	l.SetLogDir(filepath.Join(blocker, "log"))
+	l.writeToFile("[INFO] lost\n")
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "golog: create log directory:") {
+		t.Fatalf("expected a directory error, got %v", errs)
+	}
+
+	hookErr := errors.New("unreachable")
+	l.AddHook(&recordingHook{levels: LevelsFrom(LevelInfo), err: hookErr})
+	l.Info("fired")
+	if len(errs) != 2 || !errors.Is(errs[1], hookErr) {
+		t.Errorf("expected the hook error, got %v", errs)
+	}
+}
@@ -0,0 +1,77 @@
+package golog
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// ExportConfig returns a Go expression that constructs a logger with l's
+// current settings, e.g.
+//
+//	golog.NewLogger(
+//		golog.WithLevel(golog.LevelDebug),
+//		golog.WithShowDetail(true),
+//	)
+//
+// Only settings that differ from NewLogger's defaults and have an Option
+// form are included. Writers, processors, sinks, fields and custom
+// formatters are not, since they cannot be expressed as source.
+func ExportConfig(l *Logger) string {
+	cfg := l.settings()
+	var opts []string
+	if level := cfg.Level; level != LevelInfo {
+		opts = append(opts, fmt.Sprintf("golog.WithLevel(%s)", levelConst(level)))
+	}
+	if cfg.ShowDetail {
+		opts = append(opts, "golog.WithShowDetail(true)")
+	}
+	if cfg.ShowModulePath {
+		opts = append(opts, "golog.WithShowModulePath(true)")
+	}
+	if cfg.FileFormat == FileFormatJSONL {
+		opts = append(opts, "golog.WithFileFormat(golog.FileFormatJSONL)")
+	}
+	if cfg.NormalizeWhitespace {
+		opts = append(opts, "golog.WithNormalizeWhitespace(true)")
+	}
+	if f, ok := cfg.Formatter.(JSONFormatter); ok {
+		if f.PrettyPrint {
+			opts = append(opts, fmt.Sprintf("golog.WithFormatter(golog.JSONFormatter{PrettyPrint: true, PrettyLevel: %s})", levelConst(f.PrettyLevel)))
+		} else {
+			opts = append(opts, "golog.WithFormatter(golog.JSONFormatter{})")
+		}
+	}
+
+	src := "golog.NewLogger()"
+	if len(opts) > 0 {
+		src = "golog.NewLogger(\n" + strings.Join(opts, ",\n") + ",\n)"
+	}
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		// The snippet is built from fixed templates, so this is a bug.
+		panic("golog: ExportConfig produced invalid source: " + err.Error())
+	}
+	return string(formatted)
+}
+
+// levelConst returns the Go expression for level.
+func levelConst(level Level) string {
+	switch level {
+	case LevelTrace:
+		return "golog.LevelTrace"
+	case LevelDebug:
+		return "golog.LevelDebug"
+	case LevelInfo:
+		return "golog.LevelInfo"
+	case LevelWarn:
+		return "golog.LevelWarn"
+	case LevelError:
+		return "golog.LevelError"
+	case LevelPanic:
+		return "golog.LevelPanic"
+	case LevelFatal:
+		return "golog.LevelFatal"
+	}
+	return fmt.Sprintf("golog.Level(%d)", level)
+}
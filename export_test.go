@@ -0,0 +1,24 @@
+package golog
+
+import (
+	"go/parser"
+	"testing"
+)
+
+// TestExportConfig checks that the snippet is valid Go and lists non-default settings.
+func TestExportConfig(t *testing.T) {
+	if got := ExportConfig(NewLogger()); got != "golog.NewLogger()" {
+		t.Errorf("expected bare constructor for defaults, got %q", got)
+	}
+
+	l := NewLogger(WithLevel(LevelDebug), WithShowDetail(true))
+	l.SetFileFormat(FileFormatJSONL)
+	got := ExportConfig(l)
+	want := "golog.NewLogger(\n\tgolog.WithLevel(golog.LevelDebug),\n\tgolog.WithShowDetail(true),\n\tgolog.WithFileFormat(golog.FileFormatJSONL),\n)"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if _, err := parser.ParseExpr(got); err != nil {
+		t.Errorf("expected valid Go expression: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Package expvarlog publishes golog message counters through expvar.
+//
+// Nothing is registered on import; call RegisterExpvar explicitly so the
+// default expvar namespace is only populated on request.
+package expvarlog
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+
+	"github.com/ryqdev/golog"
+)
+
+// registerMutex makes checking for and publishing a set of names one step.
+var registerMutex sync.Mutex
+
+// RegisterExpvar publishes <prefix>.log.trace_total, <prefix>.log.debug_total,
+// <prefix>.log.info_total, <prefix>.log.warn_total, <prefix>.log.error_total,
+// <prefix>.log.panic_total, <prefix>.log.fatal_total and
+// <prefix>.log.dropped_total for l and its child loggers. Each variable reads
+// l.Stats when expvar is queried, so it includes messages logged before
+// registration. It returns an error, and publishes nothing, if any of the
+// names is already registered.
+func RegisterExpvar(l *golog.Logger, prefix string) error {
+	name := func(counter string) string {
+		if prefix == "" {
+			return "log." + counter
+		}
+		return prefix + ".log." + counter
+	}
+	counters := map[string]func(golog.Stats) int64{
+		name("trace_total"):   byLevel(golog.LevelTrace),
+		name("debug_total"):   byLevel(golog.LevelDebug),
+		name("info_total"):    byLevel(golog.LevelInfo),
+		name("warn_total"):    byLevel(golog.LevelWarn),
+		name("error_total"):   byLevel(golog.LevelError),
+		name("panic_total"):   byLevel(golog.LevelPanic),
+		name("fatal_total"):   byLevel(golog.LevelFatal),
+		name("dropped_total"): func(s golog.Stats) int64 { return s.Dropped },
+	}
+
+	registerMutex.Lock()
+	defer registerMutex.Unlock()
+	for n := range counters {
+		if expvar.Get(n) != nil {
+			return fmt.Errorf("expvarlog: %s is already registered", n)
+		}
+	}
+	for n, read := range counters {
+		expvar.Publish(n, expvar.Func(func() any { return read(l.Stats()) }))
+	}
+	return nil
+}
+
+func byLevel(level golog.Level) func(golog.Stats) int64 {
+	return func(s golog.Stats) int64 { return s.ByLevel[level] }
+}
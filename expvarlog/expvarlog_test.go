@@ -0,0 +1,51 @@
+package expvarlog
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ryqdev/golog"
+)
+
+// runs keeps the expvar names unique when tests run more than once per process.
+var runs atomic.Int32
+
+func uniquePrefix(t *testing.T) string {
+	return fmt.Sprintf("%s%d", t.Name(), runs.Add(1))
+}
+
+// TestRegisterExpvar checks that counters include existing stats and track new messages.
+func TestRegisterExpvar(t *testing.T) {
+	prefix := uniquePrefix(t)
+	l := golog.NewLogger(golog.WithOutput(io.Discard))
+	l.Error("before registration")
+	if err := RegisterExpvar(l, prefix); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("after registration")
+
+	if got := expvar.Get(prefix + ".log.error_total").String(); got != "1" {
+		t.Errorf("expected error_total 1, got %s", got)
+	}
+	if got := expvar.Get(prefix + ".log.info_total").String(); got != "1" {
+		t.Errorf("expected info_total 1, got %s", got)
+	}
+	if got := expvar.Get(prefix + ".log.dropped_total").String(); got != "0" {
+		t.Errorf("expected dropped_total 0, got %s", got)
+	}
+}
+
+// TestRegisterExpvarTwice checks that a repeated prefix is an error rather than a panic.
+func TestRegisterExpvarTwice(t *testing.T) {
+	prefix := uniquePrefix(t)
+	l := golog.NewLogger(golog.WithOutput(io.Discard))
+	if err := RegisterExpvar(l, prefix); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterExpvar(l, prefix); err == nil {
+		t.Error("expected an error for a name that is already registered")
+	}
+}
@@ -0,0 +1,156 @@
+package golog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Field is a key-value pair attached to every message a logger emits.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Fields maps field keys to values, for attaching several fields at once
+// with WithFields.
+type Fields map[string]any
+
+// WithTypedFields returns a child logger that appends fields to every
+// message. The parent is not modified.
+func (l *Logger) WithTypedFields(fields ...Field) *Logger {
+	child := l.clone()
+	child.fields = append(append([]Field(nil), l.fieldList()...), fields...)
+	return child
+}
+
+// WithField returns a child of the default logger that adds key=value to
+// every message.
+func WithField(key string, value any) *Logger {
+	return defaultLogger.WithField(key, value)
+}
+
+// WithFields returns a child of the default logger that adds fields to every
+// message.
+func WithFields(fields Fields) *Logger {
+	return defaultLogger.WithFields(fields)
+}
+
+// WithField returns a child logger that adds key=value to every message,
+// replacing any value l already has for key. The parent is not modified.
+func (l *Logger) WithField(key string, value any) *Logger {
+	return l.WithFields(Fields{key: value})
+}
+
+// WithFields returns a child logger that adds fields to every message. Keys
+// l already has keep their position but take the new value; new keys follow
+// in sorted order. The parent is not modified. TextFormatter writes fields
+// as key=value after the message, JSONFormatter as top-level keys.
+func (l *Logger) WithFields(fields Fields) *Logger {
+	parent := l.fieldList()
+	merged := make(map[string]any, len(parent)+len(fields))
+	for _, f := range parent {
+		merged[f.Key] = f.Value
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	child := l.clone()
+	child.fields = mergeFieldMap(parent, merged)
+	return child
+}
+
+// CorrelationIDKey is the field key WithCorrelationID stores its ID under.
+const CorrelationIDKey = "trace_id"
+
+// WithCorrelationID returns a child logger tagging every message with id, so
+// messages from several services handling one request can be matched up.
+func (l *Logger) WithCorrelationID(id string) *Logger {
+	return l.WithTypedFields(Field{Key: CorrelationIDKey, Value: id})
+}
+
+// AddFields attaches fields to every later message from l itself. Child
+// loggers created earlier keep the fields they were created with.
+func (l *Logger) AddFields(fields ...Field) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.fields = append(append([]Field(nil), l.fields...), fields...)
+}
+
+func (l *Logger) fieldList() []Field {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.fields
+}
+
+// fieldWriter is what writeFields renders into, a strings.Builder or
+// bytes.Buffer.
+type fieldWriter interface {
+	io.StringWriter
+	io.ByteWriter
+}
+
+// writeFields appends " key=value" for each field, quoting values that would
+// otherwise be ambiguous.
+func writeFields(b fieldWriter, fields []Field) {
+	for _, f := range fields {
+		b.WriteString(Whitespace)
+		b.WriteString(f.Key)
+		b.WriteByte('=')
+		b.WriteString(fieldText(f.Value))
+	}
+}
+
+func fieldText(v any) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// appendJSONFields merges fields into the JSON object obj, which must end in
+// '}'. Keys already present in obj are skipped so fields cannot shadow them.
+func appendJSONFields(obj []byte, fields []Field, reserved ...string) []byte {
+	if len(fields) == 0 {
+		return obj
+	}
+	obj = obj[:len(obj)-1]
+	for _, f := range fields {
+		if contains(reserved, f.Key) {
+			continue
+		}
+		key, _ := json.Marshal(f.Key)
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprint(f.Value))
+		}
+		obj = append(obj, ',')
+		obj = append(obj, key...)
+		obj = append(obj, ':')
+		obj = append(obj, value...)
+	}
+	return append(obj, '}')
+}
+
+func fieldMap(fields []Field) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f.Value
+	}
+	return m
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
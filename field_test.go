@@ -0,0 +1,92 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestWithTypedFields checks that fields are appended to the child's output and the parent is untouched.
+func TestWithTypedFields(t *testing.T) {
+	var buf bytes.Buffer
+	parent := NewLogger()
+	parent.w = &buf
+	child := parent.WithTypedFields(Field{"user", "bob"}, Field{"note", "two words"})
+
+	child.Info("login")
+	if got, want := buf.String(), "[INFO]"+` login user=bob note="two words" `+"\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	buf.Reset()
+	parent.Info("login")
+	if got, want := buf.String(), "[INFO] login \n"; got != want {
+		t.Errorf("expected parent output %q, got %q", want, got)
+	}
+}
+
+// TestFieldsInJSONL checks that fields become top-level keys without overriding reserved ones.
+func TestFieldsInJSONL(t *testing.T) {
+	l := NewLogger().WithTypedFields(Field{"port", 8080}, Field{"msg", "shadow"})
+	l.SetFileFormat(FileFormatJSONL)
+	rec := l.assembleMsg(l.settings(), LevelInfo, "listening")
+
+	var got map[string]any
+	if err := json.Unmarshal([]byte(l.fileLine(rec)), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["port"] != float64(8080) || got["msg"] != "listening" {
+		t.Errorf("unexpected JSONL object %v", got)
+	}
+}
+
+// TestAddFields checks that fields added in place apply to later messages only.
+func TestAddFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	child := l.WithTypedFields()
+	l.AddFields(Field{"region", "eu"})
+
+	l.Info("hello")
+	child.Info("hello")
+	if got, want := buf.String(), "[INFO] hello region=eu \n"+"[INFO] hello \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+// TestWithFields checks that chained fields are inherited, overridden and leave the parent alone.
+func TestWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	parent := NewLogger(WithOutput(&buf))
+	child := parent.WithField("user", "bob").WithFields(map[string]any{"role": "admin", "user": "alice"})
+
+	child.Info("login")
+	expected := "[INFO] login user=alice role=admin \n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	parent.Info("plain")
+	if strings.Contains(buf.String(), "user=") {
+		t.Errorf("expected the parent to stay without fields, got %q", buf.String())
+	}
+}
+
+// TestWithFieldsJSON checks that nested fields become top-level JSON keys,
+// with the child's value winning.
+func TestWithFieldsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetFormatter(JSONFormatter{})
+	l.WithFields(Fields{"user": "bob", "attempt": 1}).WithFields(Fields{"user": "alice"}).Info("login")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if got["msg"] != "login" || got["user"] != "alice" || got["attempt"] != float64(1) {
+		t.Errorf("unexpected object %v", got)
+	}
+}
@@ -0,0 +1,58 @@
+// Package fieldcrypt encrypts and decrypts individual log field values with
+// AES-256-GCM. Encrypted values are the random nonce followed by the
+// ciphertext, encoded as unpadded URL-safe base64 so they never contain '='
+// and stay unambiguous inside key=value pairs.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+)
+
+var ErrMalformed = errors.New("fieldcrypt: malformed encrypted value")
+
+// EncryptLogField encrypts value with key.
+func EncryptLogField(value string, key [32]byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptLogField reverses EncryptLogField, for offline inspection of logs.
+func DecryptLogField(encryptedValue string, key [32]byte) (string, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(encryptedValue)
+	if err != nil {
+		return "", ErrMalformed
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", ErrMalformed
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func newGCM(key [32]byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
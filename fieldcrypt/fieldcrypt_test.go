@@ -0,0 +1,23 @@
+package fieldcrypt
+
+import "testing"
+
+// TestRoundTrip checks that a value decrypts to itself and a wrong key fails.
+func TestRoundTrip(t *testing.T) {
+	key := [32]byte{1, 2, 3}
+	encrypted, err := EncryptLogField("s3cr3t value", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := DecryptLogField(encrypted, key)
+	if err != nil || plain != "s3cr3t value" {
+		t.Errorf("expected round trip, got %q, %v", plain, err)
+	}
+
+	if _, err := DecryptLogField(encrypted, [32]byte{9}); err == nil {
+		t.Error("expected error with the wrong key")
+	}
+	if _, err := DecryptLogField("!!", key); err != ErrMalformed {
+		t.Errorf("expected ErrMalformed, got %v", err)
+	}
+}
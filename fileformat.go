@@ -0,0 +1,29 @@
+package golog
+
+// FileFormat selects how entries are encoded in the log file.
+type FileFormat int
+
+const (
+	// FileFormatText writes the same lines as the console, without colors.
+	FileFormatText FileFormat = iota
+	// FileFormatJSONL writes one JSON object per line in the JSONFormatter
+	// layout, whatever formatter the console uses.
+	FileFormatJSONL
+)
+
+func SetFileFormat(f FileFormat) {
+	defaultLogger.SetFileFormat(f)
+}
+
+func (l *Logger) SetFileFormat(f FileFormat) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.FileFormat = f })
+}
+
+// fileLine renders rec for the file channel: the console line without
+// colors, or a JSON object for FileFormatJSONL.
+func (l *Logger) fileLine(rec record) string {
+	if rec.cfg.FileFormat != FileFormatJSONL {
+		return l.format(rec, false)
+	}
+	return string(JSONFormatter{}.Format(levelName(rec.level), rec.content, rec.detail("")))
+}
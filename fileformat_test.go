@@ -0,0 +1,27 @@
+package golog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestFileFormatJSONL checks that file lines are valid JSON objects in JSONL mode.
+func TestFileFormatJSONL(t *testing.T) {
+	l := NewLogger()
+	l.SetShowDetail(true)
+	l.SetFileFormat(FileFormatJSONL)
+
+	rec := l.assembleMsg(l.settings(), LevelError, "disk %s", "full")
+	line := l.fileLine(rec)
+
+	var got map[string]string
+	if err := json.Unmarshal([]byte(line), &got); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", line, err)
+	}
+	if got["level"] != "ERROR" || got["msg"] != "disk full" || got["ts"] == "" || got["file"] == "" {
+		t.Errorf("unexpected JSONL line %q", line)
+	}
+	if line[len(line)-1] != '\n' {
+		t.Error("expected line to end with a newline")
+	}
+}
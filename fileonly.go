@@ -0,0 +1,27 @@
+package golog
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// fileOnlyChannelSize is the file channel capacity of NewFileOnlyLogger,
+// larger than usual since the file is the only output.
+const fileOnlyChannelSize = 1000
+
+// NewFileOnlyLogger returns a logger that writes only to log files in dir,
+// named with pattern (see SetFilePattern), for deployments where a shipper
+// such as Filebeat or Fluentd collects the files. Console output is
+// discarded and the file writer is already running. An empty pattern
+// means DefaultFilePattern. It fails if dir cannot be created.
+func NewFileOnlyLogger(dir, pattern string) (*Logger, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("golog: create log directory: %w", err)
+	}
+	l := newLogger(make(chan fileMsg, fileOnlyChannelSize), WithOutput(io.Discard))
+	l.SetLogDir(dir)
+	l.SetFilePattern(pattern)
+	l.enableLogFile()
+	return l, nil
+}
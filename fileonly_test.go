@@ -0,0 +1,37 @@
+package golog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNewFileOnlyLogger checks that messages reach only the file and that
+// a directory that cannot be created is reported.
+func TestNewFileOnlyLogger(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	l, err := NewFileOnlyLogger(dir, "app.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cap(l.logChannel) != fileOnlyChannelSize {
+		t.Errorf("expected a channel of %d, got %d", fileOnlyChannelSize, cap(l.logChannel))
+	}
+	l.Info("shipped")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "[INFO] shipped \n" {
+		t.Errorf("unexpected file content %q", content)
+	}
+
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	os.WriteFile(blocker, nil, 0644)
+	if _, err := NewFileOnlyLogger(filepath.Join(blocker, "logs"), ""); err == nil {
+		t.Error("expected an error for a directory below a file")
+	}
+}
@@ -0,0 +1,52 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SetMaxFileSize rotates the log file once it reaches bytes, in addition to
+// the hourly rotation. The full file is renamed with the next free sequence
+// number, e.g. 2006-01-02_15.1.log, and a fresh file is opened under the
+// usual name. Zero disables size rotation.
+func (l *Logger) SetMaxFileSize(bytes int64) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.maxFileSize = bytes
+}
+
+// checkFileSize rotates the current file if it has reached maxFileSize. The
+// caller must hold logFileMutex.
+func (l *Logger) checkFileSize() {
+	if l.fileOffset < l.maxFileSize {
+		return
+	}
+	l.closeLogFile()
+	l.logFile = nil
+	rotated := sequencePath(l.logFilePath)
+	if err := os.Rename(l.logFilePath, rotated); err != nil {
+		l.reportError(fmt.Errorf("golog: rotate log file: %w", err))
+		return
+	}
+	l.rotated(rotated)
+	l.applyRetention()
+}
+
+// sequencePath returns path with the lowest ".N" before its extension, and
+// ahead of any .gz suffix, that does not exist yet.
+func sequencePath(path string) string {
+	path, gz := trimGzipExt(path)
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	if gz {
+		ext += gzipExt
+	}
+	for n := 1; ; n++ {
+		candidate := fmt.Sprintf("%s.%d%s", base, n, ext)
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package golog
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMaxFileSize checks that full files are renamed with increasing sequence numbers.
+func TestMaxFileSize(t *testing.T) {
+	l := NewLogger()
+	l.SetMaxFileSize(20)
+	path := "log/" + time.Now().Format("2006-01-02_15") + ".log"
+	first := strings.TrimSuffix(path, ".log") + ".1.log"
+	second := strings.TrimSuffix(path, ".log") + ".2.log"
+	for _, p := range []string{path, first, second} {
+		os.Remove(p)
+		defer os.Remove(p)
+	}
+
+	l.writeToFile("[INFO] first entry 1\n")
+	l.writeToFile("[INFO] second entry\n")
+	l.writeToFile("[INFO] tail\n")
+	l.closeLogFile()
+
+	for p, want := range map[string]string{
+		first:  "[INFO] first entry 1\n",
+		second: "[INFO] second entry\n",
+		path:   "[INFO] tail\n",
+	} {
+		got, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s: expected %q, got %q", p, want, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package golog
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultFlushTimeout bounds Flush when no timeout has been set.
+const DefaultFlushTimeout = 5 * time.Second
+
+var ErrFlushTimeout = errors.New("golog: flush timed out")
+
+// fileMsg is an item on the file channel: a line to write, a flush request
+// whose ack receives the result once every earlier line has been written,
+// or Close's request to stop.
+type fileMsg struct {
+	line    string
+	ack     chan error
+	stop    bool     // Set by Close: the writer exits once earlier lines are written
+	journal *journal // Set when line was journaled under seq
+	seq     uint64
+}
+
+func Flush() error {
+	return defaultLogger.Flush()
+}
+
+// Flush blocks until every message queued for the log file before the call
+// has been written, with any compressed data flushed, and synced to disk.
+// Unlike closing the logger, writing can continue afterwards. If the channel
+// stays full or the writer does not catch up within the flush timeout,
+// ErrFlushTimeout is returned. Once a writer started by StartWithContext has
+// shut down, Flush returns nil at once.
+func (l *Logger) Flush() error {
+	if !l.files.enabled.Load() {
+		return nil
+	}
+	done := l.files.writerDone()
+	timeout := l.settings().FlushTimeout
+	if timeout <= 0 {
+		timeout = DefaultFlushTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	ack := make(chan error, 1)
+	select {
+	case l.logChannel <- fileMsg{ack: ack}:
+	case <-done:
+		return nil
+	case <-timer.C:
+		return ErrFlushTimeout
+	}
+	select {
+	case err := <-ack:
+		return err
+	case <-done:
+		return nil
+	case <-timer.C:
+		return ErrFlushTimeout
+	}
+}
+
+// SetFlushTimeout bounds how long Flush may block. Zero restores DefaultFlushTimeout.
+func (l *Logger) SetFlushTimeout(d time.Duration) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.FlushTimeout = d })
+}
+
+func (l *Logger) syncFile() error {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	if l.logFile == nil {
+		return nil
+	}
+	if err := l.flushGzip(); err != nil {
+		return err
+	}
+	return l.logFile.Sync()
+}
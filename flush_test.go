@@ -0,0 +1,52 @@
+package golog
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFlush checks that queued messages are on disk once Flush returns.
+func TestFlush(t *testing.T) {
+	l := NewLogger()
+	l.w = io.Discard
+	l.setWriteLogToFile(true)
+	go l.startFileWriter(context.Background())
+	defer close(l.logChannel)
+
+	l.Info("flushed message")
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	l.logFileMutex.Lock()
+	path := l.logFilePath
+	l.logFileMutex.Unlock()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "flushed message") {
+		t.Errorf("expected message in file after Flush, got %q", content)
+	}
+
+	l.Info("still logging")
+	if err := l.Flush(); err != nil {
+		t.Errorf("expected logger to stay usable after Flush: %v", err)
+	}
+}
+
+// TestFlushTimeout checks that Flush gives up when the channel stays full.
+func TestFlushTimeout(t *testing.T) {
+	l := NewLogger()
+	l.setWriteLogToFile(true)
+	l.logChannel = make(chan fileMsg) // no writer goroutine: sends never complete
+	l.SetFlushTimeout(20 * time.Millisecond)
+
+	if err := l.Flush(); err != ErrFlushTimeout {
+		t.Errorf("expected ErrFlushTimeout, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Formatter renders a message into the line written to the console. level
+// is the upper-case level name and msg the message after every processor has
+// run.
+type Formatter interface {
+	Format(level string, msg string, detail *EntryDetail) []byte
+}
+
+// DefaultTimeFormat is the layout of the timestamp TextFormatter writes when
+// no other has been set with SetTimeFormat.
+const DefaultTimeFormat = time.RFC3339
+
+// EntryDetail carries the rest of a message to a Formatter.
+type EntryDetail struct {
+	Level      Level // The level named by Format's level argument
+	Time       time.Time
+	TimeFormat string // Set by SetTimeFormat, empty for DefaultTimeFormat
+	File       string // Caller's file, empty unless showDetail is on
+	Line       int
+	Prefix     string // Set by Named, written before the message
+	Fields     []Field
+	Color      string // Theme color for the level tag, empty when colors are off
+}
+
+// location returns "file.go:line", or "" when no caller was recorded.
+func (d *EntryDetail) location() string {
+	return record{file: d.File, line: d.Line}.location()
+}
+
+// TextFormatter writes the bracketed level tag followed by the prefix, the
+// timestamp and caller when present, the message and its fields. It is the
+// default.
+type TextFormatter struct{}
+
+// lineBufferPool holds the buffers TextFormatter renders into.
+var lineBufferPool = sync.Pool{
+	New: func() any {
+		b := new(bytes.Buffer)
+		b.Grow(128)
+		return b
+	},
+}
+
+// stringFormatter is implemented by formatters that can hand the logger its
+// line as a string directly, saving the copy out of a []byte.
+type stringFormatter interface {
+	formatString(level string, msg string, detail *EntryDetail) string
+}
+
+func (TextFormatter) Format(level string, msg string, detail *EntryDetail) []byte {
+	b := lineBufferPool.Get().(*bytes.Buffer)
+	defer putLineBuffer(b)
+	writeTextLine(b, level, msg, detail)
+	// Copy out before the buffer goes back to the pool.
+	return bytes.Clone(b.Bytes())
+}
+
+func (TextFormatter) formatString(level string, msg string, detail *EntryDetail) string {
+	b := lineBufferPool.Get().(*bytes.Buffer)
+	defer putLineBuffer(b)
+	writeTextLine(b, level, msg, detail)
+	return b.String()
+}
+
+// maxPooledLineBuffer is the largest buffer putLineBuffer keeps. Larger ones,
+// grown by an unusually long message, are left to the garbage collector
+// rather than pinned in the pool.
+const maxPooledLineBuffer = 64 << 10
+
+func putLineBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledLineBuffer {
+		return
+	}
+	b.Reset()
+	lineBufferPool.Put(b)
+}
+
+// writeTextLine renders the TextFormatter line into b.
+func writeTextLine(b *bytes.Buffer, level string, msg string, detail *EntryDetail) {
+	if detail.Color != "" {
+		b.WriteString(detail.Color)
+	}
+	b.WriteString("[" + level + "]")
+	if detail.Color != "" {
+		b.WriteString(Reset)
+	}
+	b.WriteString(Whitespace)
+	if detail.Prefix != "" {
+		b.WriteString(detail.Prefix)
+		b.WriteString(Whitespace)
+	}
+
+	if detail.File != "" {
+		layout := detail.TimeFormat
+		if layout == "" {
+			layout = DefaultTimeFormat
+		}
+		b.WriteString(detail.Time.Format(layout))
+		b.WriteString(Whitespace)
+		b.WriteString(detail.location())
+		b.WriteString(Whitespace)
+	}
+
+	b.WriteString(msg)
+	writeFields(b, detail.Fields)
+	b.WriteString(Whitespace)
+	b.WriteString(Newline)
+}
+
+// JSONFormatter writes one JSON object per line with level, ts, file, prefix
+// and msg keys, plus one key per field. Level tags are never colored.
+type JSONFormatter struct {
+	// PrettyPrint indents entries at PrettyLevel or above by two spaces over
+	// several lines; the rest stay compact. See SetJSONPrettyPrint.
+	PrettyPrint bool
+	PrettyLevel Level
+}
+
+// jsonLine is the wire format of JSONFormatter.
+type jsonLine struct {
+	Level  string `json:"level"`
+	Time   string `json:"ts"`
+	File   string `json:"file,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	Msg    string `json:"msg"`
+}
+
+func (f JSONFormatter) Format(level string, msg string, detail *EntryDetail) []byte {
+	line, _ := json.Marshal(jsonLine{
+		Level:  level,
+		Time:   detail.Time.Format(time.RFC3339Nano),
+		File:   detail.location(),
+		Prefix: detail.Prefix,
+		Msg:    msg,
+	})
+	line = appendJSONFields(line, detail.Fields, "level", "ts", "file", "prefix", "msg")
+	if f.PrettyPrint && detail.Level >= f.PrettyLevel {
+		if indented, err := json.MarshalIndent(json.RawMessage(line), "", "  "); err == nil {
+			line = indented
+		}
+	}
+	return append(line, Newline...)
+}
+
+// SetJSONPrettyPrint switches the default logger to a JSONFormatter that
+// indents entries at level or above.
+func SetJSONPrettyPrint(level Level) {
+	defaultLogger.SetJSONPrettyPrint(level)
+}
+
+// SetJSONPrettyPrint switches l to a JSONFormatter that indents entries at
+// level or above, so errors read well in a terminal while lower levels stay
+// one line each for log shippers. A JSONL log file stays compact.
+func (l *Logger) SetJSONPrettyPrint(level Level) {
+	l.SetFormatter(JSONFormatter{PrettyPrint: true, PrettyLevel: level})
+}
+
+// SetFormatter sets how the default logger renders console lines.
+func SetFormatter(f Formatter) {
+	defaultLogger.SetFormatter(f)
+}
+
+// SetFormatter sets how console lines are rendered. nil restores
+// TextFormatter. The log file follows the formatter in FileFormatText.
+func (l *Logger) SetFormatter(f Formatter) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.Formatter = f })
+}
+
+// format renders rec with the configured formatter, with the level tag
+// colored when color is set.
+func (l *Logger) format(rec record, color bool) string {
+	f := rec.cfg.Formatter
+	if f == nil {
+		f = TextFormatter{}
+	}
+	var tagColor string
+	msg := rec.content
+	if _, text := f.(TextFormatter); text && rec.cfg.NormalizeWhitespace {
+		msg = strings.Join(strings.Fields(msg), Whitespace)
+	}
+	if color && l.colorOn(rec.cfg, rec.level) {
+		tagColor = levelColor(rec.cfg, rec.level)
+		msg = l.colorKeywords(msg)
+	}
+	var line string
+	if sf, ok := f.(stringFormatter); ok {
+		line = sf.formatString(levelName(rec.level), msg, rec.detail(tagColor))
+	} else {
+		line = string(f.Format(levelName(rec.level), msg, rec.detail(tagColor)))
+	}
+	return line
+}
@@ -0,0 +1,143 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestJSONFormatter checks that console lines are JSON objects without color codes.
+func TestJSONFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf), WithFormatter(JSONFormatter{}))
+	l.SetColorEnabled(true)
+	l.SetShowDetail(true)
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		return "[p] " + format, v
+	})
+	l.Error("disk %s", "full")
+
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("expected no ANSI codes, got %q", buf.String())
+	}
+	var got map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", buf.String(), err)
+	}
+	if got["level"] != "ERROR" || got["msg"] != "[p] disk full" || got["ts"] == "" || !strings.HasPrefix(got["file"], "formatter_test.go:") {
+		t.Errorf("unexpected JSON line %q", buf.String())
+	}
+}
+
+// TestSetJSONPrettyPrint checks that entries at the pretty level or above
+// are indented and lower levels stay on one line.
+func TestSetJSONPrettyPrint(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetJSONPrettyPrint(LevelError)
+
+	l.Info("ready")
+	if lines := strings.Count(buf.String(), "\n"); lines != 1 {
+		t.Errorf("expected a compact Info line, got %q", buf.String())
+	}
+	buf.Reset()
+	l.WithTypedFields(Field{"disk", "sda"}).Error("disk full")
+	want := "{\n  \"level\": \"ERROR\",\n  \"ts\": "
+	if !strings.HasPrefix(buf.String(), want) || !strings.HasSuffix(buf.String(), ",\n  \"disk\": \"sda\"\n}\n") {
+		t.Errorf("expected an indented Error entry, got %q", buf.String())
+	}
+	var got map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil || got["msg"] != "disk full" {
+		t.Errorf("expected valid JSON, got %q: %v", buf.String(), err)
+	}
+}
+
+// TestCustomFormatter checks that a formatter receives the level name, message and detail.
+func TestCustomFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetFormatter(formatterFunc(func(level, msg string, d *EntryDetail) []byte {
+		return []byte(level + "|" + msg + "|" + d.Color + "\n")
+	}))
+	l.SetColorEnabled(false)
+	l.Warn("careful")
+	if buf.String() != "WARN|careful|\n" {
+		t.Errorf("unexpected line %q", buf.String())
+	}
+
+	buf.Reset()
+	l.SetFormatter(nil)
+	l.Info("plain")
+	if buf.String() != "[INFO] plain \n" {
+		t.Errorf("expected the text formatter back, got %q", buf.String())
+	}
+}
+
+type formatterFunc func(level, msg string, d *EntryDetail) []byte
+
+func (f formatterFunc) Format(level, msg string, d *EntryDetail) []byte {
+	return f(level, msg, d)
+}
+
+// TestSetTimeFormat checks the detail timestamp against the default and a
+// custom layout.
+func TestSetTimeFormat(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetTimeFormat("15:04:05.000")
+	l.Info("hidden detail")
+	if expected := "[INFO] hidden detail \n"; buf.String() != expected {
+		t.Errorf("expected %q without detail, got %q", expected, buf.String())
+	}
+
+	for _, layout := range []string{"15:04:05.000", ""} {
+		buf.Reset()
+		l.SetTimeFormat(layout)
+		l.SetShowDetail(true)
+		l.Info("ready")
+		if layout == "" {
+			layout = DefaultTimeFormat
+		}
+		stamp := strings.Fields(buf.String())[1]
+		if _, err := time.Parse(layout, stamp); err != nil {
+			t.Errorf("expected a timestamp in layout %q, got %q: %v", layout, buf.String(), err)
+		}
+	}
+}
+
+// TestSetTimeZone checks that detail timestamps and file names use the
+// configured zone.
+func TestSetTimeZone(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf), WithShowDetail(true))
+	l.SetTimeZone(time.UTC)
+	l.Info("ready")
+	if stamp := strings.Fields(buf.String())[1]; !strings.HasSuffix(stamp, "Z") && !strings.HasSuffix(stamp, "+00:00") {
+		t.Errorf("expected a UTC timestamp, got %q", buf.String())
+	}
+
+	kiritimati := time.FixedZone("LINT", 14*60*60)
+	l.SetTimeZone(kiritimati)
+	l.SetLogDir(t.TempDir())
+	l.writeToFile("[INFO] ready\n")
+	defer l.closeLogFile()
+	if want := time.Now().In(kiritimati).Format(DefaultFilePattern); l.currentHour != want {
+		t.Errorf("expected file %q, got %q", want, l.currentHour)
+	}
+}
+
+// TestPutLineBufferDropsLarge checks that oversized buffers are not returned to the pool.
+func TestPutLineBufferDropsLarge(t *testing.T) {
+	large := new(bytes.Buffer)
+	large.Grow(2 * maxPooledLineBuffer)
+	putLineBuffer(large)
+	for i := 0; i < 10; i++ {
+		b := lineBufferPool.Get().(*bytes.Buffer)
+		if b == large {
+			t.Fatal("expected the oversized buffer to be dropped")
+		}
+		defer putLineBuffer(b)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build go1.18
+
+package golog
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// LogValue logs key=val.String() at level. Unlike Info(format, v...), val is
+// never converted to an interface, and String is not called at all when
+// level is filtered out.
+func LogValue[T fmt.Stringer](l *Logger, level Level, key string, val T) {
+	if !l.accepts(l.settings(), capLevel(level)) {
+		return
+	}
+	l.log(level, escapeFormat(key+"="+val.String()))
+}
+
+// LogInt logs key=val at level without converting val to an interface, so a
+// filtered-out call does not allocate.
+func LogInt[T ~int | ~int64 | ~uint | ~uint64](l *Logger, level Level, key string, val T) {
+	if !l.accepts(l.settings(), capLevel(level)) {
+		return
+	}
+	var digits [20]byte
+	var text []byte
+	if val < 0 {
+		text = strconv.AppendInt(digits[:0], int64(val), 10)
+	} else {
+		text = strconv.AppendUint(digits[:0], uint64(val), 10)
+	}
+	l.log(level, escapeFormat(key+"="+string(text)))
+}
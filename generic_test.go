@@ -0,0 +1,50 @@
+//go:build go1.18
+
+package golog
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+// TestLogIntAndValue checks the rendered key=value text and caller location.
+func TestLogIntAndValue(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	type retries uint
+	LogInt(l, LevelInfo, "retries", retries(3))
+	LogInt(l, LevelWarn, "offset", int64(-12))
+	LogValue(l, LevelInfo, "took", 1500*time.Millisecond)
+	LogInt(l, LevelDebug, "hidden", 1)
+
+	want := "[INFO] retries=3 \n[WARN] offset=-12 \n[INFO] took=1.5s \n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+// TestLogIntFilteredAllocs checks that a filtered-out LogInt does not allocate.
+func TestLogIntFilteredAllocs(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+	if n := testing.AllocsPerRun(100, func() { LogInt(l, LevelDebug, "n", 42) }); n != 0 {
+		t.Errorf("expected no allocations, got %v", n)
+	}
+}
+
+func BenchmarkLogIntFiltered(b *testing.B) {
+	l := NewLogger(WithOutput(io.Discard))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		LogInt(l, LevelDebug, "n", i)
+	}
+}
+
+func BenchmarkInfoFiltered(b *testing.B) {
+	l := NewLogger(WithOutput(io.Discard))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debug("n=%d", i)
+	}
+}
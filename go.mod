@@ -1,3 +1,10 @@
 module github.com/ryqdev/golog
 
-go 1.22.5
+go 1.24.0
+
+require (
+	go.opentelemetry.io/otel v1.40.0
+	golang.org/x/term v0.20.0
+)
+
+require golang.org/x/sys v0.20.0 // indirect
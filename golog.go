@@ -2,21 +2,26 @@ package golog
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"runtime"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
 const (
-	LevelDebug Level = iota
+	LevelTrace Level = iota
+	LevelDebug
 	LevelInfo
+	LevelWarn
 	LevelError
+	LevelPanic
+	LevelFatal
 
 	Reset      = "\033[0m"
 	Red        = "\033[31m"
@@ -30,49 +35,198 @@ const (
 	Whitespace = " "
 	Newline    = "\n"
 
+	TraceLevel = Cyan + "[TRACE]" + Reset
 	InfoLevel  = Green + "[INFO]" + Reset
-	DebugLevel = Yellow + "[DEBUG]" + Reset
+	DebugLevel = Blue + "[DEBUG]" + Reset
+	WarnLevel  = Yellow + "[WARN]" + Reset
 	ErrorLevel = Red + "[ERROR]" + Reset
+	PanicLevel = Purple + "[PANIC]" + Reset
+	FatalLevel = Purple + "[FATAL]" + Reset
 )
 
+// logChannelSize is how many messages may queue for the file writer.
+const logChannelSize = 100
+
 var (
 	defaultLogger *Logger
+
+	// exitFunc ends the process after a Fatal message. Tests replace it.
+	exitFunc = os.Exit
 )
 
 type Level int32
 
 type Processor func(format string, v ...any) (string, []any)
 
+// LazyProcessor edits the formatted message in place. Lazy processors run
+// after every Processor, so they suit transformations of the final text
+// (regex rewrites, truncation, signing) that would otherwise have to format
+// the message themselves and escape it back into a format string.
+type LazyProcessor func(msg *string)
+
 type Logger struct {
-	level          Level
-	prefix         string
+	config         atomic.Pointer[LoggerConfig] // Replaced wholesale, never mutated
+	configMutex    sync.Mutex                   // Serializes config updates
+	prefix         string                       // Written between the level tag and the message, guarded by mutex
 	fileLocation   string
-	showDetail     bool
 	mutex          sync.Mutex
 	buf            bytes.Buffer
-	w              io.Writer
+	w              io.Writer            // Console output, guarded by mutex
+	levelWriters   [numLevels]io.Writer // Per-level overrides of w, guarded by mutex
 	processors     []Processor
-	writeLogToFile bool        // whether write log to file
-	logFile        *os.File    // Log file
-	logFileMutex   sync.Mutex  // Mutex for file handling
-	logChannel     chan string // Channel for log entries
-	currentHour    string      // Current hour for log file naming
+	lazyProcessors []LazyProcessor
+	logFile        *os.File     // Log file
+	logFileMutex   sync.Mutex   // Mutex for file handling
+	logChannel     chan fileMsg // Channel for log entries
+	files          *fileState   // Whether the file is enabled and its writer, shared with clones
+	currentHour    string       // Name of the current log file, from filePattern
+	logFilePath    string       // Path of the currently open log file
+	logDir         string       // Directory log files are written to, "log" if empty
+	filePattern    string       // time.Format layout naming log files, hourly if empty
+	rotationCb     func(rotatedPath string)
+	checksumAlgo   ChecksumAlgo // Sidecar checksum for rotated files, guarded by logFileMutex
+	journal        *journal     // Set by SetJournalFile, guarded by logFileMutex
+	fileErr        error        // First write error since the last Close, guarded by logFileMutex
+	compress       bool         // Gzip files opened from now on, guarded by logFileMutex
+	gzipWriter     *gzip.Writer // Compressor for the current file, nil if it is plain
+
+	pressure *pressure // Resource and memory pressure state, shared with clones
+
+	detectTruncation bool          // Reopen the log file if it shrinks externally
+	fileOffset       int64         // Expected size of the current log file
+	trackInode       bool          // Reopen the log file if it is moved or deleted
+	maxFileSize      int64         // Rotate the log file once it reaches this size
+	maxFiles         int           // Rotated files kept by SetRetention
+	maxAge           time.Duration // Age past which rotated files are deleted
+
+	preallocSize int64 // Bytes to reserve for each new log file
+	preallocated bool  // Current file was grown by preallocate and needs trimming
+
+	bannerTemplate string        // Written when a log file is opened
+	bannerFunc     func() string // Overrides bannerTemplate when set
+	fileHeader     bool          // Start new files with a format header line
+
+	routes   []Route // Set on loggers created by NewRoutingLogger, never modified, shared with clones
+	fallback *Logger // Receives messages no route matches
+
+	sinks []sink // Extra writers with their own level, guarded by mutex
+	hooks []Hook // Called with written entries, guarded by mutex
+
+	fields []Field // Attached to every message, never mutated in place
+
+	testTB TB // Failed by Error messages under SetTestMode, guarded by mutex
+
+	recordFilters []func(record) bool // Drop a message when any returns false, guarded by mutex
+	tagFilters    []tagFilter         // Set by AddTagFilter, guarded by mutex
+	keywordColors []keywordColor      // Set by AddKeywordColor, guarded by mutex
+	contextKeys   []contextKey        // Set by RegisterContextKey, guarded by mutex
+	dedup         *messageDedup       // Set by SetDeduplication, guarded by mutex
+
+	middleware []func(Entry) Entry // Set by Wrap, applied innermost first
+
+	throughput *ThroughputLimiter // Set by ThroughputLimiter.Wrap, shared with clones
+
+	callerFilter func(runtime.Frame) bool // Reports whether a caller may log, guarded by mutex
+
+	stats       *loggerStats   // Shared with child loggers
+	limits      *rateLimits    // Shared with child loggers
+	sampling    *sampler       // Shared with child loggers
+	subscribers *subscribers   // Shared with child loggers
+	recent      *recentEntries // Shared with child loggers
+	onceKeys    *sync.Map      // Keys seen by LogOnce, shared with child loggers
+	auditTrail  *auditLog      // Shared with child loggers
 }
 
 func init() {
 	defaultLogger = NewLogger()
 }
 
-func NewLogger() *Logger {
+func NewLogger(opts ...Option) *Logger {
+	return newLogger(make(chan fileMsg, logChannelSize), opts...) // Buffered channel to avoid blocking
+}
+
+// newLogger returns a logger with the defaults and the given file channel.
+func newLogger(logChannel chan fileMsg, opts ...Option) *Logger {
 	logger := &Logger{
-		level:      LevelInfo,
-		w:          os.Stderr,
-		showDetail: false,
-		logChannel: make(chan string, 100), // Buffered channel to avoid blocking
+		w:           stderr,
+		logChannel:  logChannel,
+		files:       &fileState{},
+		stats:       newLoggerStats(),
+		limits:      &rateLimits{},
+		sampling:    newSampler(),
+		pressure:    newPressure(),
+		subscribers: &subscribers{},
+		recent:      &recentEntries{},
+		onceKeys:    &sync.Map{},
+		auditTrail:  &auditLog{},
+	}
+	logger.config.Store(&LoggerConfig{
+		Level:        LevelInfo,
+		Theme:        DefaultTheme,
+		ColorEnabled: colorAllowedByEnv(),
+		ColorAuto:    true,
+	})
+	for _, opt := range opts {
+		opt(logger)
 	}
 	return logger
 }
 
+// Clone returns a copy of l for per-request customization. The copy shares
+// l's writers, file channel, stats and rate limits, so no goroutine is
+// started, but gets its own processors, fields, prefix and settings:
+// changing them on either logger does not affect the other.
+func (l *Logger) Clone() *Logger {
+	return l.clone()
+}
+
+// clone returns a copy of l that shares its writer and file channel but owns
+// its own processor chain.
+func (l *Logger) clone() *Logger {
+	child := new(Logger)
+	l.cloneInto(child)
+	return child
+}
+
+// cloneInto overwrites dst with a clone of l, discarding everything dst held.
+func (l *Logger) cloneInto(dst *Logger) {
+	processors, lazyProcessors := l.processorList()
+	*dst = Logger{
+		prefix:         l.prefixText(),
+		fileLocation:   l.fileLocation,
+		w:              l.writer(),
+		levelWriters:   l.levelWriterList(),
+		processors:     processors,
+		lazyProcessors: lazyProcessors,
+		logChannel:     l.logChannel,
+		files:          l.files,
+		journal:        l.journalFile(),
+		sinks:          l.sinkList(),
+		hooks:          l.hookList(),
+		fields:         l.fieldList(),
+		callerFilter:   l.callerFilterFunc(),
+		testTB:         l.testTBFunc(),
+		recordFilters:  l.recordFilterList(),
+		tagFilters:     l.tagFilterList(),
+		keywordColors:  l.keywordColorList(),
+		contextKeys:    l.contextKeyList(),
+		dedup:          l.messageDedup(),
+		middleware:     l.middleware,
+		throughput:     l.throughput,
+		routes:         l.routes,
+		fallback:       l.fallback,
+		stats:          l.stats,
+		limits:         l.limits,
+		sampling:       l.sampling,
+		pressure:       l.pressure,
+		subscribers:    l.subscribers,
+		recent:         l.recent,
+		onceKeys:       l.onceKeys,
+		auditTrail:     l.auditTrail,
+	}
+	dst.config.Store(l.settings())
+}
+
 func SetLevel(level Level) {
 	defaultLogger.SetLevel(level)
 }
@@ -81,112 +235,513 @@ func GetLevel() Level {
 	return defaultLogger.GetLevel()
 }
 
+func Trace(format string, v ...any) {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	defaultLogger.log(LevelTrace, format, v...)
+}
+
+func Tracef(format string, v ...any) {
+	defaultLogger.log(LevelTrace, format, v...)
+}
+
 func Info(format string, v ...any) {
-	defaultLogger.Info(format, v...)
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	defaultLogger.log(LevelInfo, format, v...)
+}
+
+func Infof(format string, v ...any) {
+	defaultLogger.log(LevelInfo, format, v...)
 }
 
 func Debug(format string, v ...any) {
-	defaultLogger.Debug(format, v...)
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	defaultLogger.log(LevelDebug, format, v...)
+}
+
+func Debugf(format string, v ...any) {
+	defaultLogger.log(LevelDebug, format, v...)
+}
+
+func Warn(format string, v ...any) {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	defaultLogger.log(LevelWarn, format, v...)
+}
+
+func Warnf(format string, v ...any) {
+	defaultLogger.log(LevelWarn, format, v...)
 }
 
 func Error(format string, v ...any) {
-	defaultLogger.Error(format, v...)
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	defaultLogger.log(LevelError, format, v...)
+}
+
+func Errorf(format string, v ...any) {
+	defaultLogger.log(LevelError, format, v...)
+}
+
+func Panic(format string, v ...any) {
+	defaultLogger.log(LevelPanic, format, v...)
+	if capLevel(LevelPanic) == LevelPanic {
+		panic(fmt.Sprintf(format, v...))
+	}
+}
+
+func Fatal(format string, v ...any) {
+	defaultLogger.log(LevelFatal, format, v...)
+	if capLevel(LevelFatal) == LevelFatal {
+		defaultLogger.exit()
+	}
 }
 
 func AddProcessor(p Processor) {
 	defaultLogger.AddProcessor(p)
 }
 
+func SetProcessors(ps ...Processor) {
+	defaultLogger.SetProcessors(ps...)
+}
+
+func RemoveProcessors() {
+	defaultLogger.RemoveProcessors()
+}
+
 func ShowDetail(b bool) {
-	defaultLogger.showDetail = b
+	defaultLogger.SetShowDetail(b)
+}
+
+func SetTimeFormat(layout string) {
+	defaultLogger.SetTimeFormat(layout)
 }
 
 func SetLogFile(path string) {
-	defaultLogger.writeLogToFile = true
-	go defaultLogger.startFileWriter() // Start the goroutine for log writing
+	defaultLogger.enableLogFile() // Start the goroutine for log writing
+}
+
+// DisableLogFile stops sending messages to the log file, for environments
+// whose filesystem is ephemeral or read-only. Messages already queued are
+// still written.
+func (l *Logger) DisableLogFile() {
+	l.setWriteLogToFile(false)
+}
+
+// SetShowDetail includes the timestamp and caller location in each line.
+func (l *Logger) SetShowDetail(b bool) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.ShowDetail = b })
+}
+
+// SetTimeFormat sets the time.Format layout of the timestamp SetShowDetail
+// adds. An empty layout restores DefaultTimeFormat. The layout is kept while
+// detail is off.
+func (l *Logger) SetTimeFormat(layout string) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.TimeFormat = layout })
 }
 
 func (l *Logger) SetLevel(level Level) {
-	atomic.StoreInt32((*int32)(&l.level), int32(level))
+	l.Transact(func(cfg *LoggerConfig) { cfg.Level = level })
 }
 
 func (l *Logger) GetLevel() Level {
-	return Level(atomic.LoadInt32((*int32)(&l.level)))
+	return l.settings().Level
 }
 
-func (l *Logger) Info(format string, v ...any) {
-	if l.level > LevelInfo {
-		return
+// levelName returns the upper-case name used in level tags.
+func levelName(level Level) string {
+	switch level {
+	case LevelTrace:
+		return "TRACE"
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	case LevelPanic:
+		return "PANIC"
+	case LevelFatal:
+		return "FATAL"
 	}
-	msg := l.assembleMsg(format, v...)
-	l.w.Write([]byte(InfoLevel + msg)) // Write to standard output
-	if l.writeLogToFile {
-		l.logChannel <- "[INFO]" + msg // Send log to channel for file writing
+	return fmt.Sprintf("LEVEL(%d)", level)
+}
+
+// enabled reports whether a message at level passes both the level in cfg
+// and any temporary floor raised by resource-aware leveling.
+func (l *Logger) enabled(cfg *LoggerConfig, level Level) bool {
+	if !levelAtLeast(cfg.LevelOrder, level, cfg.Level) {
+		return false
+	}
+	return level >= Level(l.pressure.levelFloor.Load())
+}
+
+// Trace logs at LevelTrace, below Debug, for output too verbose for debug
+// builds such as per-packet dumps.
+func (l *Logger) Trace(format string, v ...any) {
+	if len(v) == 0 {
+		format = escapeFormat(format)
 	}
+	l.log(LevelTrace, format, v...)
+}
+
+// Tracef is Trace for printf-style callers; format is always treated as a
+// format string.
+func (l *Logger) Tracef(format string, v ...any) {
+	l.log(LevelTrace, format, v...)
+}
+
+// Info logs at LevelInfo. With arguments, format is a printf format; without
+// any, it is logged as is, so "100% done" needs no escaping. Trace, Debug,
+// Warn and Error behave the same way, and the f-suffixed variants always
+// treat format as a format string.
+func (l *Logger) Info(format string, v ...any) {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	l.log(LevelInfo, format, v...)
+}
+
+// Infof is Info for printf-style callers; format is always treated as a
+// format string.
+func (l *Logger) Infof(format string, v ...any) {
+	l.log(LevelInfo, format, v...)
 }
 
 func (l *Logger) Debug(format string, v ...any) {
-	if l.level > LevelDebug {
-		return
+	if len(v) == 0 {
+		format = escapeFormat(format)
 	}
-	msg := l.assembleMsg(format, v...)
-	l.w.Write([]byte(DebugLevel + msg))
-	if l.writeLogToFile {
-		l.logChannel <- "[DEBUG]" + msg
+	l.log(LevelDebug, format, v...)
+}
+
+// Debugf is Debug for printf-style callers; format is always treated as a
+// format string.
+func (l *Logger) Debugf(format string, v ...any) {
+	l.log(LevelDebug, format, v...)
+}
+
+func (l *Logger) Warn(format string, v ...any) {
+	if len(v) == 0 {
+		format = escapeFormat(format)
 	}
+	l.log(LevelWarn, format, v...)
+}
+
+// Warnf is Warn for printf-style callers; format is always treated as a
+// format string.
+func (l *Logger) Warnf(format string, v ...any) {
+	l.log(LevelWarn, format, v...)
 }
 
 func (l *Logger) Error(format string, v ...any) {
-	if l.level > LevelError {
+	if len(v) == 0 {
+		format = escapeFormat(format)
+	}
+	l.log(LevelError, format, v...)
+}
+
+// Errorf is Error for printf-style callers; format is always treated as a
+// format string.
+func (l *Logger) Errorf(format string, v ...any) {
+	l.log(LevelError, format, v...)
+}
+
+// Panic logs at LevelPanic and then panics with the formatted message. Under
+// a global level ceiling below LevelPanic it only logs.
+func (l *Logger) Panic(format string, v ...any) {
+	l.log(LevelPanic, format, v...)
+	if capLevel(LevelPanic) == LevelPanic {
+		panic(fmt.Sprintf(format, v...))
+	}
+}
+
+// Fatal logs at LevelFatal, waits for the log file to catch up and then
+// exits the process with status 1. Under a global level ceiling below
+// LevelFatal it only logs.
+func (l *Logger) Fatal(format string, v ...any) {
+	l.log(LevelFatal, format, v...)
+	if capLevel(LevelFatal) == LevelFatal {
+		l.exit()
+	}
+}
+
+// exit flushes the file channel and ends the process.
+func (l *Logger) exit() {
+	if err := l.Flush(); err != nil {
+		l.reportError(fmt.Errorf("golog: flush before exit: %w", err))
+	}
+	exitFunc(1)
+}
+
+// SetNormalizeWhitespace collapses every run of whitespace in the message,
+// including newlines, to a single space and trims it at both ends. It applies
+// to TextFormatter output only; other formatters, such as JSONFormatter, get
+// the message unchanged.
+func (l *Logger) SetNormalizeWhitespace(b bool) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.NormalizeWhitespace = b })
+}
+
+// log is the shared path behind the level methods. Exported wrappers, both
+// Logger methods and package-level functions, must call it directly so the
+// caller frame depth in assembleMsg stays the same. It returns the message
+// as written, or a zero Entry if it was filtered out. The configuration is
+// loaded once and travels with the record, so a concurrent Transact never
+// applies to half of a message.
+func (l *Logger) log(level Level, format string, v ...any) Entry {
+	cfg := l.settings()
+	level = capLevel(level)
+	if l.shed(level) || !l.accepts(cfg, level) || !l.sampled(cfg, level, format) || l.rateLimited(level) {
+		return Entry{}
+	}
+	l.reportSuppressed(context.Background(), cfg, level)
+	rec := l.assembleMsg(cfg, level, format, v...)
+	if rec, ok := l.dispatch(context.Background(), rec); ok {
+		return rec.entry()
+	}
+	return Entry{}
+}
+
+// logCtx is log for the Ctx variants. The file channel send gives up once
+// ctx is done.
+func (l *Logger) logCtx(ctx context.Context, level Level, format string, v ...any) {
+	cfg := l.settings()
+	level = capLevel(level)
+	if l.shed(level) || !l.accepts(cfg, level) || !l.sampled(cfg, level, format) || l.rateLimited(level) {
 		return
 	}
-	msg := l.assembleMsg(format, v...)
-	l.w.Write([]byte(ErrorLevel + msg))
-	if l.writeLogToFile {
-		l.logChannel <- "[ERROR]" + msg
+	l.reportSuppressed(ctx, cfg, level)
+	rec := l.assembleMsg(cfg, level, format, v...)
+	if cfg.AutoInjectBaggage {
+		if fields := baggageFields(ctx); fields != nil {
+			rec.fields = append(append([]Field(nil), rec.fields...), fields...)
+		}
 	}
+	l.dispatch(ctx, rec)
+}
+
+// dispatch runs the filters and middleware on an assembled record and hands
+// it to the routes or emit. It returns the record after middleware and
+// whether the filters let it through.
+func (l *Logger) dispatch(ctx context.Context, rec record) (record, bool) {
+	if !l.callerAllowed(rec.caller) || !l.tagsAllowed(rec) || !l.recordAllowed(rec) {
+		return rec, false
+	}
+	rec, ok := l.deduplicate(rec)
+	if !ok {
+		return rec, false
+	}
+	rec = l.applyMiddleware(rec)
+	l.failTest(rec)
+	if l.routes != nil {
+		l.route(ctx, rec)
+		return rec, true
+	}
+	l.emit(ctx, rec)
+	return rec, true
+}
+
+// emit writes an assembled record to the console and the file channel.
+func (l *Logger) emit(ctx context.Context, rec record) {
+	line := l.format(rec, true)
+	if l.enabled(rec.cfg, rec.level) {
+		// Write to standard output
+		if err := l.writeConsole(rec.level, line); err == errDropped {
+			l.stats.countDropped(rec.level)
+		} else {
+			l.stats.count(rec.level)
+			l.stats.countTags(rec)
+		}
+		l.subscribers.publish(rec)
+		l.recent.add(rec)
+		l.fireHooks(rec)
+		if l.files.enabled.Load() {
+			fm := fileMsg{line: l.fileLine(rec)}
+			if j := l.journalFile(); j != nil {
+				fm.journal, fm.seq = j, j.record(fm.line)
+			}
+			l.sendFile(ctx, fm)
+		}
+	}
+	l.writeSinks(rec.level, line)
+}
+
+// SetRotationCallback registers fn to be called with the path of each log
+// file after it has been rotated out and closed. fn runs in its own goroutine
+// so slow callbacks (uploads, compression) do not stall the file writer.
+func (l *Logger) SetRotationCallback(fn func(rotatedPath string)) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.rotationCb = fn
 }
 
 func (l *Logger) AddProcessor(p Processor) {
-	l.processors = append(l.processors, p)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	processors := make([]Processor, len(l.processors), len(l.processors)+1)
+	copy(processors, l.processors)
+	l.processors = append(processors, p)
+}
+
+func (l *Logger) AddLazyProcessor(p LazyProcessor) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	processors := make([]LazyProcessor, len(l.lazyProcessors), len(l.lazyProcessors)+1)
+	copy(processors, l.lazyProcessors)
+	l.lazyProcessors = append(processors, p)
+}
+
+// SetProcessors replaces l's processor chain with ps. Lazy processors are
+// kept. Messages already being assembled finish with the chain they started
+// with.
+func (l *Logger) SetProcessors(ps ...Processor) {
+	processors := append([]Processor(nil), ps...)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.processors = processors
+}
+
+// RemoveProcessors clears l's processors, lazy ones included. Global
+// processors still run.
+func (l *Logger) RemoveProcessors() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.processors = nil
+	l.lazyProcessors = nil
 }
 
-func (l *Logger) assembleMsg(format string, v ...any) string {
-	var msg strings.Builder
-	msg.WriteString(Whitespace)
+func (l *Logger) processorList() ([]Processor, []LazyProcessor) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.processors, l.lazyProcessors
+}
 
-	if l.showDetail {
-		msg.WriteString(time.Now().String())
-		msg.WriteString(Whitespace)
-		getFileLocation := func() string {
-			_, file, line, ok := runtime.Caller(4)
+// record holds the parts of a log line before it is rendered.
+type record struct {
+	level   Level
+	time    time.Time
+	file    string // Caller's file as rendered, only set when showDetail is on
+	line    int
+	prefix  string
+	content string
+	fields  []Field
+	caller  runtime.Frame // Set when showDetail or a caller filter is on
+
+	cfg *LoggerConfig // Settings the message was assembled under
+}
+
+func (l *Logger) assembleMsg(cfg *LoggerConfig, level Level, format string, v ...any) record {
+	rec := record{level: level, time: cfg.now(), prefix: l.prefixText(), fields: l.fieldList(), cfg: cfg}
+	filter := l.callerFilterFunc()
+	if cfg.ShowDetail || filter != nil {
+		getCaller := func() runtime.Frame {
+			pc, file, line, ok := runtime.Caller(4 + cfg.CallerSkip)
 			if !ok {
-				file = "unknown file"
-				line = -1
+				return runtime.Frame{File: "unknown file", Line: -1}
+			}
+			frame := runtime.Frame{PC: pc, File: file, Line: line}
+			if fn := runtime.FuncForPC(pc); fn != nil {
+				frame.Function = fn.Name()
 			}
-			return fmt.Sprintf("%s:%d", filepath.Base(file), line) + " "
+			return frame
 		}
+		rec.caller = getCaller()
+		if cfg.ShowDetail {
+			rec.file, rec.line = filepath.Base(rec.caller.File), rec.caller.Line
+			if pkg := funcPackage(rec.caller.Function); cfg.ShowModulePath && pkg != "" {
+				rec.file = pkg + "/" + rec.file
+			}
+		}
+	}
+	rec.content = l.getContent(cfg, format, v...)
+	return rec
+}
 
-		msg.WriteString(getFileLocation())
+// location returns "file.go:line", or "" when no caller was recorded.
+func (r record) location() string {
+	if r.file == "" {
+		return ""
 	}
+	return fmt.Sprintf("%s:%d", r.file, r.line)
+}
 
-	msg.WriteString(l.getContent(format, v...))
-	msg.WriteString(Whitespace)
-	msg.WriteString(Newline)
+// entry converts the record to its public form.
+func (r record) entry() Entry {
+	return Entry{
+		Level:   r.level,
+		Message: r.content,
+		Time:    r.time,
+		File:    r.file,
+		Line:    r.line,
+		Fields:  fieldMap(r.fields),
+	}
+}
+
+// detail returns the parts of the record a Formatter receives besides the
+// level and message.
+func (r record) detail(color string) *EntryDetail {
+	return &EntryDetail{Level: r.level, Time: r.time, TimeFormat: r.cfg.TimeFormat, File: r.file, Line: r.line, Prefix: r.prefix, Fields: r.fields, Color: color}
+}
+
+func (l *Logger) getContent(cfg *LoggerConfig, format string, v ...any) string {
+	processors, lazyProcessors := l.processorList()
+	return processMessage(cfg, globalProcessorList(), processors, lazyProcessors, format, v...)
+}
 
-	return msg.String()
+// processMessage formats the message through the given processor chain.
+func processMessage(cfg *LoggerConfig, globals, processors []Processor, lazyProcessors []LazyProcessor, format string, v ...any) string {
+	v = evalLazyArgs(v)
+	for _, process := range globals {
+		format, v = runProcessor(cfg, process, format, v)
+	}
+	for _, process := range processors {
+		format, v = runProcessor(cfg, process, format, v)
+	}
+	msg := fmt.Sprintf(format, v...)
+	if cfg.AutoFormatJSON {
+		msg = indentJSON(msg)
+	}
+	for _, process := range lazyProcessors {
+		runLazyProcessor(cfg, process, &msg)
+	}
+	return msg
 }
 
-func (l *Logger) getContent(format string, v ...any) string {
-	for _, process := range l.processors {
-		format, v = process(format, v...)
+// startFileWriter writes messages from the file channel until Close asks
+// it to stop, the channel is closed or ctx is done.
+func (l *Logger) startFileWriter(ctx context.Context) {
+	for {
+		select {
+		case msg, ok := <-l.logChannel:
+			if !ok || msg.stop {
+				return
+			}
+			l.handleFileMsg(msg)
+		case <-ctx.Done():
+			l.drainAndClose()
+			return
+		}
 	}
-	return fmt.Sprintf(format, v...)
 }
 
-func (l *Logger) startFileWriter() {
-	for msg := range l.logChannel {
-		l.writeToFile(msg)
+// handleFileMsg writes a line to the log file or answers a flush request.
+func (l *Logger) handleFileMsg(msg fileMsg) {
+	if msg.ack != nil {
+		msg.ack <- l.syncFile()
+		return
+	}
+	l.writeToFile(msg.line)
+	if msg.journal != nil {
+		msg.journal.consume(msg.seq, msg.line)
 	}
 }
 
@@ -194,28 +749,104 @@ func (l *Logger) writeToFile(msg string) {
 	l.logFileMutex.Lock()
 	defer l.logFileMutex.Unlock()
 
-	currentHour := time.Now().Format("2006-01-02_15")
+	currentHour := l.now().Format(l.filePatternOrDefault())
 	if l.logFile == nil || l.currentHour != currentHour {
 		if l.logFile != nil {
-			l.logFile.Close()
+			l.closeLogFile()
+			l.rotated(l.logFilePath)
 		}
 
-		// write to log directory, if there doesn't exist, create it
-		if _, err := os.Stat("log"); os.IsNotExist(err) {
-			os.Mkdir("log", os.ModePerm)
-		}
+		l.openLogFile(currentHour)
+		l.applyRetention()
+	}
 
-		filePath := fmt.Sprintf("log/%s.log", currentHour)
-		file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-		if err != nil {
-			fmt.Println("Error opening file:", err)
-			return
+	if l.logFile != nil {
+		l.writeFileString(msg)
+		if l.detectTruncation {
+			l.checkTruncation()
+		}
+		if l.trackInode && l.logFile != nil {
+			l.checkInode()
+		}
+		if l.maxFileSize > 0 && l.logFile != nil {
+			l.checkFileSize()
 		}
-		l.logFile = file
-		l.currentHour = currentHour
 	}
+}
 
-	if l.logFile != nil {
-		l.logFile.WriteString(msg)
+// openLogFile opens the file called name in the log directory as the current
+// log file and writes its header and banner. The caller must hold
+// logFileMutex and have closed any previous file.
+func (l *Logger) openLogFile(name string) {
+	// write to log directory, if there doesn't exist, create it
+	dir := l.logDirOrDefault()
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		l.reportError(fmt.Errorf("golog: create log directory: %w", err))
+		return
+	}
+
+	filePath := filepath.Join(dir, name)
+	if l.compress {
+		filePath += gzipExt
+	}
+	flags := os.O_APPEND | os.O_CREATE | os.O_WRONLY
+	if l.preallocSize > 0 && !l.compress && !preallocKeepsSize {
+		// Writes go to the tracked offset, which O_APPEND forbids.
+		flags = os.O_CREATE | os.O_WRONLY
+	}
+	file, err := os.OpenFile(filePath, flags, 0644)
+	if err != nil {
+		l.reportError(fmt.Errorf("golog: open log file: %w", err))
+		return
+	}
+	l.logFile = file
+	l.logFilePath = filePath
+	l.currentHour = name
+	l.fileOffset, _ = file.Seek(0, io.SeekEnd)
+	if l.compress {
+		// Appending to an existing archive adds a gzip member, which
+		// readers decompress as one stream.
+		l.gzipWriter = gzip.NewWriter(file)
+	} else if l.preallocSize > 0 {
+		l.preallocated = preallocate(file, l.fileOffset, l.preallocSize)
+	}
+	if l.fileHeader && l.fileOffset == 0 {
+		l.writeFileString(l.fileHeaderLine())
+	}
+	if banner := l.fileBanner(); banner != "" {
+		l.writeFileString(banner)
+	}
+}
+
+// writeFileString writes to the current file at the tracked offset. The
+// caller must hold logFileMutex.
+func (l *Logger) writeFileString(msg string) {
+	var n int
+	var err error
+	if l.gzipWriter != nil {
+		n, err = l.gzipWriter.Write([]byte(msg))
+	} else if l.preallocated {
+		n, err = l.logFile.WriteAt([]byte(msg), l.fileOffset)
+	} else {
+		n, err = l.logFile.WriteString(msg)
+	}
+	l.fileOffset += int64(n)
+	if err != nil && l.fileErr == nil {
+		l.fileErr = err
+		l.reportError(fmt.Errorf("golog: write log file: %w", err))
+	}
+}
+
+// closeLogFile closes the current file, first ending its gzip stream or
+// trimming any preallocated space past the last write. The caller must hold
+// logFileMutex.
+func (l *Logger) closeLogFile() {
+	if err := l.closeGzip(); err != nil && l.fileErr == nil {
+		l.fileErr = err
+	}
+	if l.preallocated {
+		l.logFile.Truncate(l.fileOffset)
+		l.preallocated = false
 	}
+	l.logFile.Close()
 }
@@ -3,7 +3,10 @@ package golog
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -13,8 +16,8 @@ func TestLoggerInitialization(t *testing.T) {
 	if GetLevel() != LevelInfo {
 		t.Errorf("expected log level %v, got %v", LevelInfo, GetLevel())
 	}
-	if defaultLogger.w != os.Stderr {
-		t.Error("expected default writer to be os.Stderr")
+	if sw, ok := defaultLogger.w.(*safeWriter); !ok || sw.w != os.Stderr {
+		t.Error("expected default writer to be a safe writer around os.Stderr")
 	}
 }
 
@@ -33,7 +36,7 @@ func TestInfoLogging(t *testing.T) {
 	SetLevel(LevelInfo)
 	Info("test info message")
 
-	expected := fmt.Sprintf("%s test info message \n", InfoLevel)
+	expected := "[INFO] test info message \n"
 	if buf.String() != expected {
 		t.Errorf("expected %q, got %q", expected, buf.String())
 	}
@@ -46,12 +49,54 @@ func TestDebugLogging(t *testing.T) {
 	SetLevel(LevelDebug)
 	Debug("test debug message")
 
-	expected := fmt.Sprintf("%s test debug message \n", DebugLevel)
+	expected := "[DEBUG] test debug message \n"
 	if buf.String() != expected {
 		t.Errorf("expected %q, got %q", expected, buf.String())
 	}
 }
 
+// TestWarnLogging checks that Warn passes at LevelWarn while Info is suppressed.
+func TestWarnLogging(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetLevel(LevelWarn)
+	l.Info("test info message")
+	l.Warn("test warn message")
+
+	expected := "[WARN] test warn message \n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+// TestTraceLogging checks that Trace passes only at LevelTrace and that the
+// file line carries the plain tag.
+func TestTraceLogging(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetLevel(LevelDebug)
+	l.Trace("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("expected trace to be suppressed at LevelDebug, got %q", buf.String())
+	}
+
+	l.SetLevel(LevelTrace)
+	l.Trace("packet %x", []byte{0xde, 0xad})
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+	expected := "[TRACE] packet dead \n[DEBUG] debug \n[INFO] info \n[WARN] warn \n[ERROR] error \n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	rec := l.assembleMsg(l.settings(), LevelTrace, "packet")
+	if got := l.fileLine(rec); got != "[TRACE] packet \n" {
+		t.Errorf("expected plain trace tag in file line, got %q", got)
+	}
+}
+
 // TestErrorLogging checks that Error messages are correctly logged.
 func TestErrorLogging(t *testing.T) {
 	var buf bytes.Buffer
@@ -59,7 +104,7 @@ func TestErrorLogging(t *testing.T) {
 	SetLevel(LevelError)
 	Error("test error message")
 
-	expected := fmt.Sprintf("%s test error message \n", ErrorLevel)
+	expected := "[ERROR] test error message \n"
 	if buf.String() != expected {
 		t.Errorf("expected %q, got %q", expected, buf.String())
 	}
@@ -79,12 +124,51 @@ func TestAddProcessor(t *testing.T) {
 	SetLevel(LevelInfo)
 	Info("test info message")
 
-	expected := fmt.Sprintf("%s [PREFIX] test info message \n", InfoLevel)
+	expected := "[INFO] [PREFIX] test info message \n"
 	if buf.String() != expected {
 		t.Errorf("expected %q, got %q", expected, buf.String())
 	}
 }
 
+// TestSetProcessors checks replacing and clearing the processor chain while
+// other goroutines log.
+func TestSetProcessors(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		return "[OLD] " + format, v
+	})
+	l.SetProcessors(func(format string, v ...any) (string, []any) {
+		return "[NEW] " + format, v
+	})
+	l.Info("msg")
+	if expected := "[INFO] [NEW] msg \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Info("concurrent")
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		l.SetProcessors(func(format string, v ...any) (string, []any) { return format, v })
+		l.RemoveProcessors()
+	}
+	wg.Wait()
+
+	buf.Reset()
+	l.Info("msg")
+	if expected := "[INFO] msg \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
 // Test with log file
 func TestWithLogFile(t *testing.T) {
 	SetLogFile("test.log")
@@ -95,3 +179,186 @@ func TestWithLogFile(t *testing.T) {
 	Error("test error message")
 	time.Sleep(1 * time.Second)
 }
+
+// TestRotationCallback checks that the callback receives the path of the rotated file.
+func TestRotationCallback(t *testing.T) {
+	l := NewLogger()
+	l.SetLogDir(t.TempDir())
+	rotated := make(chan string, 1)
+	l.SetRotationCallback(func(path string) { rotated <- path })
+
+	l.writeToFile("first\n")
+	first := l.logFilePath
+	l.currentHour = "stale"
+	l.writeToFile("second\n")
+	defer l.logFile.Close()
+
+	select {
+	case got := <-rotated:
+		if got != first {
+			t.Errorf("expected rotated path %q, got %q", first, got)
+		}
+	case <-time.After(time.Second):
+		t.Error("rotation callback was not called")
+	}
+}
+
+// TestNormalizeWhitespace checks that rendered lines have no double spaces.
+func TestNormalizeWhitespace(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetShowDetail(true)
+	l.SetNormalizeWhitespace(true)
+	l.Info("%s  spaced\tout %s", "", "")
+
+	out := strings.TrimPrefix(buf.String(), "[INFO]")
+	if strings.Contains(out, "  ") || strings.Contains(out, "\t") {
+		t.Errorf("expected no whitespace runs, got %q", out)
+	}
+	if !strings.HasSuffix(out, " spaced out \n") {
+		t.Errorf("expected collapsed message ending in newline, got %q", out)
+	}
+}
+
+// TestNormalizeWhitespaceJSON checks that JSON lines, pretty-printed or not, are left alone.
+func TestNormalizeWhitespaceJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetFormatter(JSONFormatter{PrettyPrint: true})
+	l.SetNormalizeWhitespace(true)
+	l.Info("two  spaces")
+
+	if !strings.Contains(buf.String(), "\n  \"msg\": \"two  spaces\"") {
+		t.Errorf("expected indented JSON with the message intact, got %q", buf.String())
+	}
+}
+
+// TestAddLazyProcessor checks that lazy processors see the formatted message after eager ones.
+func TestAddLazyProcessor(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		return "[EAGER] " + format, v
+	})
+	l.AddLazyProcessor(func(msg *string) {
+		*msg = strings.ToUpper(*msg)
+	})
+	l.Info("user %s has 100%% quota", "bob")
+
+	expected := fmt.Sprintf("%s [EAGER] USER BOB HAS 100%% QUOTA \n", "[INFO]")
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+// TestPanicLogging checks that Panic writes the message and then panics with it.
+func TestPanicLogging(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	defer func() {
+		if r := recover(); r != "disk 3 failed" {
+			t.Errorf("expected panic with message, got %v", r)
+		}
+		expected := "[PANIC] disk 3 failed \n"
+		if buf.String() != expected {
+			t.Errorf("expected %q, got %q", expected, buf.String())
+		}
+	}()
+	l.Panic("disk %d failed", 3)
+}
+
+// TestFatalLogging checks that Fatal writes the message and exits with status 1.
+func TestFatalLogging(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	code := -1
+	exitFunc = func(c int) { code = c }
+	defer func() { exitFunc = os.Exit }()
+
+	l.Fatal("cannot continue")
+	if code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	expected := "[FATAL] cannot continue \n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+// TestPlainAndFormattedVariants checks that messages without arguments are
+// logged literally while the f variants always format.
+func TestPlainAndFormattedVariants(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf), WithLevel(LevelDebug))
+	l.Info("100% done")
+	l.Infof("100%% done")
+	l.Debugf("%d items", 3)
+	l.Error("disk %s", "full")
+	l.Errorf("rate 5%%")
+
+	want := "[INFO] 100% done \n[INFO] 100% done \n[DEBUG] 3 items \n[ERROR] disk full \n[ERROR] rate 5% \n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func BenchmarkInfoAllocs(b *testing.B) {
+	l := NewLogger(WithOutput(io.Discard))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("request %d served", i)
+	}
+}
+
+// TestClone checks that processors, fields and settings added to a clone
+// leave the original alone while both keep writing to the same output.
+func TestClone(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		return "[APP] " + format, v
+	})
+	c := l.Clone()
+	c.AddProcessor(func(format string, v ...any) (string, []any) {
+		return "[REQ] " + format, v
+	})
+	c.AddFields(Field{Key: "request", Value: 7})
+	c.SetLevel(LevelDebug)
+
+	c.Debug("handled")
+	l.Debug("hidden")
+	l.Info("idle")
+	expected := "[DEBUG] [REQ] [APP] handled request=7 \n[INFO] [APP] idle \n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func BenchmarkInfoConcurrent(b *testing.B) {
+	l := NewLogger(WithOutput(io.Discard))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			l.Info("request %d served", i)
+		}
+	})
+}
+
+func BenchmarkInfoWithProcessors(b *testing.B) {
+	l := NewLogger(WithOutput(io.Discard))
+	for i := 0; i < 3; i++ {
+		l.AddProcessor(func(format string, v ...any) (string, []any) { return format, v })
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("request %d served", i)
+	}
+}
+
+func BenchmarkAssembleMsg(b *testing.B) {
+	l := NewLogger(WithOutput(io.Discard))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.assembleMsg(l.settings(), LevelInfo, "request %d served", i)
+	}
+}
@@ -0,0 +1,26 @@
+package golog
+
+import "hash/fnv"
+
+func SetHashFunc(fn func(msg string) uint64) {
+	defaultLogger.SetHashFunc(fn)
+}
+
+// SetHashFunc sets the hash SetDeduplication and SetMessageSampling key
+// messages by, e.g. a faster one such as xxhash, or a keyed cryptographic
+// one where users can choose what gets logged. fn is called from every
+// goroutine that logs, so it must be safe for concurrent use. It must also
+// be deterministic and rarely collide on the messages a program actually
+// logs: messages with the same hash count as one, so a collision drops or
+// thins out a message that is not a repeat. nil restores the defaults,
+// exact comparison for deduplication and 64-bit FNV-1a for sampling.
+func (l *Logger) SetHashFunc(fn func(msg string) uint64) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.HashFunc = fn })
+}
+
+// fnv64a is the default hash of SetMessageSampling.
+func fnv64a(msg string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(msg))
+	return h.Sum64()
+}
@@ -0,0 +1,39 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestSetHashFuncDeduplication checks that deduplication compares hashes, so
+// with a hash that is always 0 every message after the first is dropped.
+func TestSetHashFuncDeduplication(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetDeduplication(time.Minute, 0)
+	l.SetHashFunc(func(string) uint64 { return 0 })
+
+	l.Info("disk full")
+	l.Info("db unreachable")
+	l.Info("cache cold")
+	if got, want := buf.String(), "[INFO] disk full \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+// TestSetHashFuncSampling checks that message sampling counts per hash, so
+// format strings with the same hash share one counter.
+func TestSetHashFuncSampling(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetMessageSampling(2)
+	l.SetHashFunc(func(string) uint64 { return 0 })
+
+	l.Info("a")
+	l.Info("b")
+	l.Info("c")
+	if got, want := buf.String(), "[INFO] a \n[INFO] c \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,72 @@
+package golog
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// FileHeaderPrefix starts the header line written by SetFileHeaderVersion.
+	FileHeaderPrefix = "# golog "
+	// FileHeaderVersion is the current version of the file layout.
+	FileHeaderVersion = 1
+)
+
+// SetFileHeaderVersion makes each new log file start with a line such as
+// "# golog v1 format=jsonl rotation=hourly", so readers can tell how the rest
+// of the file is encoded and when it was rotated. rotation follows
+// SetFilePattern, e.g. daily, with "+size" appended under SetMaxFileSize,
+// or is "size" or "none" when the pattern has no time in it. Files reopened after a restart already have a
+// header and are not given a second one.
+func (l *Logger) SetFileHeaderVersion(b bool) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.fileHeader = b
+}
+
+// fileHeaderLine returns the header for a file created now. The caller must
+// hold logFileMutex.
+func (l *Logger) fileHeaderLine() string {
+	format := "text"
+	if l.settings().FileFormat == FileFormatJSONL {
+		format = "jsonl"
+	}
+	return fmt.Sprintf("%sv%d format=%s rotation=%s\n", FileHeaderPrefix, FileHeaderVersion, format, l.rotationName())
+}
+
+// rotationPeriods lists the periods a file pattern can rotate at, shortest
+// first, with the time one period after the reference time.
+var rotationPeriods = []struct {
+	name string
+	next func(time.Time) time.Time
+}{
+	{"minutely", func(t time.Time) time.Time { return t.Add(time.Minute) }},
+	{"hourly", func(t time.Time) time.Time { return t.Add(time.Hour) }},
+	{"daily", func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }},
+	{"monthly", func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+	{"yearly", func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }},
+}
+
+// rotationName describes when files are rotated: the shortest period that
+// changes the file name, plus "size" when SetMaxFileSize is on. The caller
+// must hold logFileMutex.
+func (l *Logger) rotationName() string {
+	pattern := l.filePatternOrDefault()
+	ref := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	period := ""
+	for _, p := range rotationPeriods {
+		if ref.Format(pattern) != p.next(ref).Format(pattern) {
+			period = p.name
+			break
+		}
+	}
+	switch {
+	case l.maxFileSize > 0 && period != "":
+		return period + "+size"
+	case l.maxFileSize > 0:
+		return "size"
+	case period != "":
+		return period
+	}
+	return "none"
+}
@@ -0,0 +1,42 @@
+package golog
+
+import "testing"
+
+// TestFileHeaderVersion checks that the header precedes the banner and reflects the file format.
+func TestFileHeaderVersion(t *testing.T) {
+	l := NewLogger()
+	l.SetFileHeaderVersion(true)
+	l.SetFileFormat(FileFormatJSONL)
+	l.SetFileBannerFunc(func() string { return "=== started ===" })
+
+	lines := readFreshLogFile(t, l, "{}\n")
+	if lines[0] != "# golog v1 format=jsonl rotation=hourly" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if lines[1] != "=== started ===" || lines[2] != "{}" {
+		t.Errorf("expected banner then entry after header, got %q", lines[1:])
+	}
+}
+
+// TestFileHeaderRotation checks that the rotation in the header follows the
+// file pattern and the size limit.
+func TestFileHeaderRotation(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		size    int64
+		want    string
+	}{
+		{"", 0, "hourly"},
+		{"app_2006-01-02.log", 0, "daily"},
+		{"app_2006-01.log", 1 << 20, "monthly+size"},
+		{"app.log", 1 << 20, "size"},
+		{"app.log", 0, "none"},
+	} {
+		l := NewLogger()
+		l.SetFilePattern(tc.pattern)
+		l.SetMaxFileSize(tc.size)
+		if got := l.rotationName(); got != tc.want {
+			t.Errorf("pattern %q, size %d: expected %q, got %q", tc.pattern, tc.size, tc.want, got)
+		}
+	}
+}
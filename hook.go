@@ -0,0 +1,71 @@
+package golog
+
+import "fmt"
+
+// Hook reacts to fully assembled entries, e.g. to forward errors to a
+// tracking service or count them in a metrics system.
+type Hook interface {
+	// Levels returns the levels Fire is called for.
+	Levels() []Level
+	// Fire is called with each entry at one of Levels. An error goes to the
+	// handler set with SetErrorHandler, or to stderr without one, and does
+	// not stop other hooks or the log file.
+	Fire(entry Entry) error
+}
+
+// LevelsFrom returns min and every built-in level above it, for Hook.Levels.
+func LevelsFrom(min Level) []Level {
+	var levels []Level
+	for level := min; level <= LevelFatal; level++ {
+		levels = append(levels, level)
+	}
+	return levels
+}
+
+// AddHook registers h for the entries l writes. Hooks run synchronously,
+// after the console write and before the entry is queued for the log file,
+// so a slow hook slows logging down. Child loggers created afterwards
+// inherit the hook.
+func (l *Logger) AddHook(h Hook) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	hooks := make([]Hook, len(l.hooks), len(l.hooks)+1)
+	copy(hooks, l.hooks)
+	l.hooks = append(hooks, h)
+}
+
+func (l *Logger) hookList() []Hook {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.hooks
+}
+
+func (l *Logger) fireHooks(rec record) {
+	hooks := l.hookList()
+	if len(hooks) == 0 {
+		return
+	}
+	var entry *Entry
+	for _, h := range hooks {
+		if !hookWants(h, rec.level) {
+			continue
+		}
+		if entry == nil {
+			e := rec.entry()
+			entry = &e
+		}
+		// Reported outside the logger so a failing hook cannot recurse.
+		if err := h.Fire(*entry); err != nil {
+			l.reportError(fmt.Errorf("hook: %w", err))
+		}
+	}
+}
+
+func hookWants(h Hook, level Level) bool {
+	for _, l := range h.Levels() {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
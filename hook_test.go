@@ -0,0 +1,58 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// recordingHook keeps the entries it is fired with.
+type recordingHook struct {
+	levels  []Level
+	entries []Entry
+	err     error
+}
+
+func (h *recordingHook) Levels() []Level { return h.levels }
+
+func (h *recordingHook) Fire(e Entry) error {
+	h.entries = append(h.entries, e)
+	return h.err
+}
+
+// TestAddHook checks that hooks see entries at their levels only, before
+// the call returns.
+func TestAddHook(t *testing.T) {
+	l := NewLogger(WithOutput(&bytes.Buffer{}))
+	h := &recordingHook{levels: LevelsFrom(LevelWarn)}
+	l.AddHook(h)
+
+	l.Info("quiet")
+	l.WithField("disk", "sda").Error("failed")
+
+	if len(h.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %+v", h.entries)
+	}
+	if e := h.entries[0]; e.Level != LevelError || e.Message != "failed" || e.Fields["disk"] != "sda" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+}
+
+// TestHookError checks that a failing hook does not stop the others or the
+// message itself.
+func TestHookError(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	failing := &recordingHook{levels: []Level{LevelInfo}, err: errors.New("unreachable")}
+	next := &recordingHook{levels: []Level{LevelInfo}}
+	l.AddHook(failing)
+	l.AddHook(next)
+
+	l.Info("still logged")
+	if len(failing.entries) != 1 || len(next.entries) != 1 {
+		t.Errorf("expected both hooks to fire, got %d and %d", len(failing.entries), len(next.entries))
+	}
+	if buf.String() != "[INFO] still logged \n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
@@ -0,0 +1,117 @@
+// Package httplog logs HTTP request and response bodies, up to a size
+// limit, with credentials scrubbed from their headers.
+package httplog
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/ryqdev/golog"
+)
+
+// DefaultMaxBodyBytes is how much of a response body LogResponseWriter
+// captures unless its MaxBodyBytes is changed.
+const DefaultMaxBodyBytes = 4 << 10
+
+// Redacted replaces the values of scrubbed headers.
+const Redacted = "[REDACTED]"
+
+// scrubbedHeaders carry credentials and are never logged.
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+type body struct {
+	io.Reader
+	io.Closer
+}
+
+// LogRequest logs r's method, URL, headers and up to maxBodyBytes of its body
+// at Debug, and returns a shallow copy of r whose body still yields every
+// byte, including those read for logging.
+func LogRequest(l *golog.Logger, r *http.Request, maxBodyBytes int64) *http.Request {
+	var head bytes.Buffer
+	r2 := r.WithContext(r.Context())
+	if r.Body != nil && r.Body != http.NoBody {
+		io.Copy(io.Discard, io.TeeReader(io.LimitReader(r.Body, maxBodyBytes), &head))
+		r2.Body = body{io.MultiReader(bytes.NewReader(head.Bytes()), r.Body), r.Body}
+	}
+	l.WithTypedFields(
+		golog.Field{Key: "method", Value: r.Method},
+		golog.Field{Key: "url", Value: r.URL.String()},
+		golog.Field{Key: "headers", Value: scrub(r.Header)},
+		golog.Field{Key: "body", Value: head.String()},
+	).Debug("http request")
+	return r2
+}
+
+// LoggingResponseWriter records the status code and the start of the body
+// written through it, for Log.
+type LoggingResponseWriter struct {
+	http.ResponseWriter
+	// MaxBodyBytes is how much of the body is captured. Writes past it are
+	// still sent to the client.
+	MaxBodyBytes int64
+
+	l      *golog.Logger
+	status int
+	body   bytes.Buffer
+}
+
+// LogResponseWriter wraps w so the response can be logged with Log once the
+// handler returns.
+func LogResponseWriter(l *golog.Logger, w http.ResponseWriter) *LoggingResponseWriter {
+	return &LoggingResponseWriter{ResponseWriter: w, MaxBodyBytes: DefaultMaxBodyBytes, l: l}
+}
+
+func (w *LoggingResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *LoggingResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if room := w.MaxBodyBytes - int64(w.body.Len()); room > 0 {
+		w.body.Write(p[:min(int64(len(p)), room)])
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Unwrap returns the wrapped writer, for http.ResponseController.
+func (w *LoggingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Status returns the status code sent, 200 if the handler only wrote a
+// body and 0 if it wrote nothing.
+func (w *LoggingResponseWriter) Status() int {
+	return w.status
+}
+
+// Body returns the captured start of the body.
+func (w *LoggingResponseWriter) Body() []byte {
+	return w.body.Bytes()
+}
+
+// Log logs the status, headers and captured body at Debug.
+func (w *LoggingResponseWriter) Log() {
+	w.l.WithTypedFields(
+		golog.Field{Key: "status", Value: w.Status()},
+		golog.Field{Key: "headers", Value: scrub(w.Header())},
+		golog.Field{Key: "body", Value: w.body.String()},
+	).Debug("http response")
+}
+
+// scrub returns a copy of h with credential headers redacted.
+func scrub(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range scrubbedHeaders {
+		if _, ok := h[name]; ok {
+			h[name] = []string{Redacted}
+		}
+	}
+	return h
+}
@@ -0,0 +1,61 @@
+package httplog
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ryqdev/golog"
+)
+
+// TestLogRequest checks that the logged body is truncated, credentials are
+// scrubbed and the handler still reads the whole body.
+func TestLogRequest(t *testing.T) {
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf), golog.WithLevel(golog.LevelDebug))
+	r := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader("user=bob&pass=hunter2"))
+	r.Header.Set("Authorization", "Bearer secret")
+	r.Header.Set("Cookie", "session=secret")
+
+	r = LogRequest(l, r, 8)
+	rest, _ := io.ReadAll(r.Body)
+	if string(rest) != "user=bob&pass=hunter2" {
+		t.Errorf("expected the full body to be restored, got %q", rest)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "http request") || !strings.Contains(out, `body="user=bob"`) || strings.Contains(out, "pass=") {
+		t.Errorf("expected the body truncated to 8 bytes, got %q", out)
+	}
+	if strings.Contains(out, "secret") || !strings.Contains(out, Redacted) {
+		t.Errorf("expected credentials to be redacted, got %q", out)
+	}
+}
+
+// TestLogResponseWriter checks that status and body are captured up to the
+// limit while the client receives everything.
+func TestLogResponseWriter(t *testing.T) {
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf), golog.WithLevel(golog.LevelDebug))
+	rec := httptest.NewRecorder()
+	w := LogResponseWriter(l, rec)
+	w.MaxBodyBytes = 5
+	w.Header().Set("Set-Cookie", "session=secret")
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte("hello "))
+	w.Write([]byte("world"))
+	w.Log()
+
+	if rec.Body.String() != "hello world" || rec.Code != http.StatusCreated {
+		t.Errorf("expected the client to get everything, got %d %q", rec.Code, rec.Body.String())
+	}
+	if w.Status() != http.StatusCreated || string(w.Body()) != "hello" {
+		t.Errorf("expected 201 and a 5 byte body, got %d %q", w.Status(), w.Body())
+	}
+	out := buf.String()
+	if !strings.Contains(out, "status=201") || strings.Contains(out, "secret") {
+		t.Errorf("unexpected response log %q", out)
+	}
+}
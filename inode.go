@@ -0,0 +1,21 @@
+package golog
+
+// SetInodeTracking makes the file writer notice when the log file is moved
+// or deleted by an external tool (e.g. logrotate with nocreate) and open a
+// new file at the same path. It is only supported on Linux and macOS and is
+// ignored elsewhere.
+func (l *Logger) SetInodeTracking(b bool) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.trackInode = b
+}
+
+// checkInode reopens the log file if its path no longer refers to the open
+// file. The caller must hold logFileMutex.
+func (l *Logger) checkInode() {
+	if !fileMoved(l.logFile, l.logFilePath) {
+		return
+	}
+	l.closeLogFile()
+	l.openLogFile(l.currentHour)
+}
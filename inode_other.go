@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package golog
+
+import "os"
+
+func fileMoved(file *os.File, path string) bool {
+	return false
+}
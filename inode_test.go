@@ -0,0 +1,41 @@
+//go:build linux || darwin
+
+package golog
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// TestInodeTracking checks that a moved log file is replaced by a new one at the same path.
+func TestInodeTracking(t *testing.T) {
+	l := NewLogger()
+	l.SetInodeTracking(true)
+	path := "log/" + time.Now().Format("2006-01-02_15") + ".log"
+	os.Remove(path)
+	defer os.Remove(path)
+
+	l.writeToFile("[INFO] before\n")
+	moved := path + ".1"
+	if err := os.Rename(path, moved); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(moved)
+
+	l.writeToFile("[INFO] during\n")
+	l.writeToFile("[INFO] after\n")
+	l.closeLogFile()
+
+	old, _ := os.ReadFile(moved)
+	if string(old) != "[INFO] before\n[INFO] during\n" {
+		t.Errorf("unexpected moved file %q", old)
+	}
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(current) != "[INFO] after\n" {
+		t.Errorf("expected only the later entry in the new file, got %q", current)
+	}
+}
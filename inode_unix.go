@@ -0,0 +1,21 @@
+//go:build linux || darwin
+
+package golog
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileMoved reports whether path is missing or is a different inode than file.
+func fileMoved(file *os.File, path string) bool {
+	open, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	current, err := os.Stat(path)
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	return open.Sys().(*syscall.Stat_t).Ino != current.Sys().(*syscall.Stat_t).Ino
+}
@@ -0,0 +1,141 @@
+package golog
+
+import (
+	"bufio"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// journalCompactSize is the journal size past which it is truncated as soon
+// as no message is outstanding.
+const journalCompactSize = 1 << 20
+
+// journal records each line sent to the file channel and marks it consumed
+// once written, so lines still in the channel when the process dies can be
+// replayed. Records are:
+//
+//	W <seq> <quoted line>
+//	C <seq> <crc32 of line>
+type journal struct {
+	mutex   sync.Mutex
+	file    *os.File
+	seq     uint64
+	pending int
+	size    int64
+	report  func(error) // The owning logger's error handler
+}
+
+// SetJournalFile journals lines queued for the log file in path. Lines a
+// previous run queued but never wrote are first replayed into the log file,
+// as RecoverJournal does, and the journal then starts afresh. Journal writes
+// are not synced, so this protects against process crashes, not power loss.
+func (l *Logger) SetJournalFile(path string) error {
+	if _, err := RecoverJournal(path, l); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("golog: open journal: %w", err)
+	}
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	if l.journal != nil {
+		l.journal.file.Close()
+	}
+	l.journal = &journal{file: file, report: l.reportError}
+	return nil
+}
+
+// RecoverJournal writes the lines recorded in the journal at path but never
+// marked consumed to l's log file, in their original order, and returns how
+// many there were. The journal itself is left unchanged.
+func RecoverJournal(path string, l *Logger) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	unconsumed := make(map[uint64]string)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		kind, rest, _ := strings.Cut(scanner.Text(), " ")
+		seqText, payload, _ := strings.Cut(rest, " ")
+		seq, err := strconv.ParseUint(seqText, 10, 64)
+		if err != nil {
+			continue // Torn final record from a crash mid-write
+		}
+		switch kind {
+		case "W":
+			if line, err := strconv.Unquote(payload); err == nil {
+				unconsumed[seq] = line
+			}
+		case "C":
+			if line, ok := unconsumed[seq]; ok && payload == checksum(line) {
+				delete(unconsumed, seq)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("golog: read journal: %w", err)
+	}
+
+	seqs := make([]uint64, 0, len(unconsumed))
+	for seq := range unconsumed {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	for _, seq := range seqs {
+		l.writeToFile(unconsumed[seq])
+	}
+	return len(seqs), nil
+}
+
+func checksum(line string) string {
+	return strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(line))), 16)
+}
+
+func (l *Logger) journalFile() *journal {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	return l.journal
+}
+
+// record journals line and returns its sequence number.
+func (j *journal) record(line string) uint64 {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.seq++
+	j.pending++
+	j.write(fmt.Sprintf("W %d %s\n", j.seq, strconv.Quote(line)))
+	return j.seq
+}
+
+// consume marks seq as written to the log file.
+func (j *journal) consume(seq uint64, line string) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.pending--
+	if j.pending == 0 && j.size > journalCompactSize {
+		if err := j.file.Truncate(0); err == nil {
+			j.file.Seek(0, 0)
+			j.size = 0
+			return
+		}
+	}
+	j.write(fmt.Sprintf("C %d %s\n", seq, checksum(line)))
+}
+
+func (j *journal) write(record string) {
+	n, err := j.file.WriteString(record)
+	j.size += int64(n)
+	if err != nil {
+		j.report(fmt.Errorf("golog: journal write failed: %w", err))
+	}
+}
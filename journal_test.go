@@ -0,0 +1,61 @@
+package golog
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRecoverJournal checks that only lines left in the channel at a crash are replayed.
+func TestRecoverJournal(t *testing.T) {
+	logPath := "log/" + time.Now().Format("2006-01-02_15") + ".log"
+	os.Remove(logPath)
+	defer os.Remove(logPath)
+	journalPath := filepath.Join(t.TempDir(), "journal")
+
+	l := NewLogger(WithOutput(io.Discard))
+	l.setWriteLogToFile(true) // No writer goroutine: lines stay queued as if it crashed
+	if err := l.SetJournalFile(journalPath); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("written")
+	l.Info("lost")
+
+	// Let the "writer" handle only the first line before the crash.
+	msg := <-l.logChannel
+	l.writeToFile(msg.line)
+	msg.journal.consume(msg.seq, msg.line)
+	l.closeLogFile()
+	l.logFile = nil
+
+	recovered := NewLogger()
+	n, err := RecoverJournal(journalPath, recovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered.closeLogFile()
+	if n != 1 {
+		t.Errorf("expected 1 recovered line, got %d", n)
+	}
+	content, _ := os.ReadFile(logPath)
+	if got := string(content); strings.Count(got, "written") != 1 || strings.Count(got, "lost") != 1 {
+		t.Errorf("expected each line exactly once in the log file, got %q", got)
+	}
+
+	// SetJournalFile performs the same recovery, then starts the journal afresh.
+	os.Remove(logPath)
+	again := NewLogger()
+	if err := again.SetJournalFile(journalPath); err != nil {
+		t.Fatal(err)
+	}
+	again.closeLogFile()
+	if content, _ := os.ReadFile(logPath); !strings.Contains(string(content), "lost") || strings.Contains(string(content), "written") {
+		t.Errorf("expected only the lost line to be replayed, got %q", content)
+	}
+	if info, _ := os.Stat(journalPath); info.Size() != 0 {
+		t.Errorf("expected a fresh journal, got %d bytes", info.Size())
+	}
+}
@@ -0,0 +1,64 @@
+// Package k8slog tags golog messages with Kubernetes pod metadata exposed
+// through the Downward API.
+package k8slog
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ryqdev/golog"
+)
+
+// labelsPath is where a downwardAPI volume conventionally mounts pod labels.
+var labelsPath = "/etc/podinfo/labels"
+
+var envFields = []struct{ env, key string }{
+	{"POD_NAME", "pod_name"},
+	{"POD_NAMESPACE", "pod_namespace"},
+	{"NODE_NAME", "node_name"},
+	{"POD_IP", "pod_ip"},
+}
+
+// InjectKubernetesMetadata attaches pod_name, pod_namespace, node_name and
+// pod_ip from the environment to every message l emits, plus each pod label
+// as label.<name> when the labels file is mounted. Outside Kubernetes, when
+// none of the variables are set, it does nothing.
+func InjectKubernetesMetadata(l *golog.Logger) {
+	var fields []golog.Field
+	for _, f := range envFields {
+		if v := os.Getenv(f.env); v != "" {
+			fields = append(fields, golog.Field{Key: f.key, Value: v})
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	fields = append(fields, readLabels(labelsPath)...)
+	l.AddFields(fields...)
+}
+
+// readLabels parses the Downward API labels format, one key="value" per line.
+// A missing or unreadable file yields no fields.
+func readLabels(path string) []golog.Field {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var fields []golog.Field
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || key == "" {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		fields = append(fields, golog.Field{Key: "label." + key, Value: value})
+	}
+	return fields
+}
@@ -0,0 +1,52 @@
+package k8slog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ryqdev/golog"
+)
+
+// TestInjectKubernetesMetadata checks that env vars and labels become fields.
+func TestInjectKubernetesMetadata(t *testing.T) {
+	t.Setenv("POD_NAME", "api-7d9f")
+	t.Setenv("POD_NAMESPACE", "prod")
+	t.Setenv("NODE_NAME", "")
+	t.Setenv("POD_IP", "10.0.0.5")
+
+	labelsPath = filepath.Join(t.TempDir(), "labels")
+	defer func() { labelsPath = "/etc/podinfo/labels" }()
+	os.WriteFile(labelsPath, []byte("app=\"api\"\ntier=\"backend\"\n"), 0644)
+
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf))
+	InjectKubernetesMetadata(l)
+	l.Info("ready")
+
+	out := buf.String()
+	for _, want := range []string{"pod_name=api-7d9f", "pod_namespace=prod", "pod_ip=10.0.0.5", "label.app=api", "label.tier=backend"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+	if strings.Contains(out, "node_name") {
+		t.Errorf("expected unset NODE_NAME to be skipped, got %q", out)
+	}
+}
+
+// TestOutsideKubernetes checks that nothing is attached when the env vars are absent.
+func TestOutsideKubernetes(t *testing.T) {
+	for _, f := range envFields {
+		t.Setenv(f.env, "")
+	}
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf))
+	InjectKubernetesMetadata(l)
+	l.Info("ready")
+	if strings.Contains(buf.String(), "=") {
+		t.Errorf("expected no fields, got %q", buf.String())
+	}
+}
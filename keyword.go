@@ -0,0 +1,43 @@
+package golog
+
+import "regexp"
+
+type keywordColor struct {
+	pattern *regexp.Regexp
+	color   string
+}
+
+// AddKeywordColor colors every case-insensitive occurrence of keyword in
+// console messages with ansiCode, e.g. Red. Keywords apply in the order they
+// were added. Like level colors, this only affects the console and only when
+// colors are enabled; the log file gets the plain message.
+func (l *Logger) AddKeywordColor(keyword, ansiCode string) {
+	kc := keywordColor{pattern: regexp.MustCompile(`(?i)` + regexp.QuoteMeta(keyword)), color: ansiCode}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	colors := make([]keywordColor, len(l.keywordColors), len(l.keywordColors)+1)
+	copy(colors, l.keywordColors)
+	l.keywordColors = append(colors, kc)
+}
+
+// ClearKeywordColors removes every keyword added by AddKeywordColor.
+func (l *Logger) ClearKeywordColors() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.keywordColors = nil
+}
+
+func (l *Logger) keywordColorList() []keywordColor {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.keywordColors
+}
+
+func (l *Logger) colorKeywords(msg string) string {
+	for _, kc := range l.keywordColorList() {
+		msg = kc.pattern.ReplaceAllStringFunc(msg, func(match string) string {
+			return kc.color + match + Reset
+		})
+	}
+	return msg
+}
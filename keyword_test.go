@@ -0,0 +1,32 @@
+package golog
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// TestAddKeywordColor checks that keywords are colored on the console but not in the file.
+func TestAddKeywordColor(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf), WithLevel(LevelDebug))
+	l.SetColorEnabled(true)
+	l.AddKeywordColor("error", Red)
+	l.Debug("retrying after ERROR")
+
+	expected := fmt.Sprintf("%s retrying after %sERROR%s \n", DebugLevel, Red, Reset)
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+	rec := l.assembleMsg(l.settings(), LevelDebug, "retrying after ERROR")
+	if line := l.fileLine(rec); line != "[DEBUG] retrying after ERROR \n" {
+		t.Errorf("expected an uncolored file line, got %q", line)
+	}
+
+	buf.Reset()
+	l.ClearKeywordColors()
+	l.Debug("another error")
+	if expected := fmt.Sprintf("%s another error \n", DebugLevel); buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
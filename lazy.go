@@ -0,0 +1,102 @@
+package golog
+
+// lazyEval is implemented by arguments whose value is computed only once a
+// message is known to be written.
+type lazyEval interface {
+	evalLazy() any
+}
+
+type lazyArg struct {
+	fn func() any
+}
+
+func (a lazyArg) evalLazy() any {
+	return a.fn()
+}
+
+// Lazy wraps fn as a log argument that is evaluated only if the message is
+// written, so expensive values cost nothing at disabled levels:
+//
+//	l.Debug("state: %v", golog.Lazy(func() any { return dump() }))
+func Lazy(fn func() any) lazyArg {
+	return lazyArg{fn}
+}
+
+// lazyArgs wraps each of fns with Lazy.
+func lazyArgs(fns []func() any) []any {
+	v := make([]any, len(fns))
+	for i, fn := range fns {
+		v[i] = lazyArg{fn}
+	}
+	return v
+}
+
+// evalLazyArgs returns v with every lazy argument replaced by its value,
+// copying v only if it holds one.
+func evalLazyArgs(v []any) []any {
+	var out []any
+	for i, arg := range v {
+		lazy, ok := arg.(lazyEval)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = append([]any(nil), v...)
+		}
+		out[i] = lazy.evalLazy()
+	}
+	if out == nil {
+		return v
+	}
+	return out
+}
+
+func TraceLazy(format string, args ...func() any) {
+	defaultLogger.log(LevelTrace, format, lazyArgs(args)...)
+}
+
+func DebugLazy(format string, args ...func() any) {
+	defaultLogger.log(LevelDebug, format, lazyArgs(args)...)
+}
+
+func InfoLazy(format string, args ...func() any) {
+	defaultLogger.log(LevelInfo, format, lazyArgs(args)...)
+}
+
+func WarnLazy(format string, args ...func() any) {
+	defaultLogger.log(LevelWarn, format, lazyArgs(args)...)
+}
+
+func ErrorLazy(format string, args ...func() any) {
+	defaultLogger.log(LevelError, format, lazyArgs(args)...)
+}
+
+// TraceLazy is Tracef with each argument computed by calling it, only if
+// the message is written.
+func (l *Logger) TraceLazy(format string, args ...func() any) {
+	l.log(LevelTrace, format, lazyArgs(args)...)
+}
+
+// DebugLazy is Debugf with each argument computed by calling it, only if
+// the message is written.
+func (l *Logger) DebugLazy(format string, args ...func() any) {
+	l.log(LevelDebug, format, lazyArgs(args)...)
+}
+
+// InfoLazy is Infof with each argument computed by calling it, only if the
+// message is written.
+func (l *Logger) InfoLazy(format string, args ...func() any) {
+	l.log(LevelInfo, format, lazyArgs(args)...)
+}
+
+// WarnLazy is Warnf with each argument computed by calling it, only if the
+// message is written.
+func (l *Logger) WarnLazy(format string, args ...func() any) {
+	l.log(LevelWarn, format, lazyArgs(args)...)
+}
+
+// ErrorLazy is Errorf with each argument computed by calling it, only if
+// the message is written.
+func (l *Logger) ErrorLazy(format string, args ...func() any) {
+	l.log(LevelError, format, lazyArgs(args)...)
+}
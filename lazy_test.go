@@ -0,0 +1,33 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestLazyArgs checks that lazy arguments are evaluated only for messages
+// that are written.
+func TestLazyArgs(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	calls := 0
+	expensive := func() any {
+		calls++
+		return "dump"
+	}
+
+	l.DebugLazy("state: %v", expensive)
+	l.Debug("state: %v", Lazy(expensive))
+	if calls != 0 {
+		t.Fatalf("expected no evaluation at a disabled level, got %d", calls)
+	}
+
+	l.InfoLazy("state: %v", expensive)
+	l.Warn("state: %v, id %d", Lazy(expensive), 7)
+	if calls != 2 {
+		t.Errorf("expected 2 evaluations, got %d", calls)
+	}
+	if expected := "[INFO] state: dump \n[WARN] state: dump, id 7 \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
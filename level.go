@@ -0,0 +1,56 @@
+package golog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseLevel returns the level named s, such as "info" or "ERROR", for
+// levels read from configuration.
+func ParseLevel(s string) (Level, error) {
+	for level := LevelTrace; level <= LevelFatal; level++ {
+		if strings.EqualFold(s, levelName(level)) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("golog: unknown level %q", s)
+}
+
+// String returns the lower-case name of the level, the form ParseLevel
+// accepts.
+func (l Level) String() string {
+	return strings.ToLower(levelName(l))
+}
+
+// SetLevelOrder ranks levels from least to most severe for the SetLevel
+// filter, for severity models that do not match the numeric order of the
+// levels, such as custom levels between the built-in ones. Levels missing
+// from the list rank below all of them, so they are suppressed unless the
+// minimum level is itself missing. An empty list restores the numeric order.
+// The temporary floor raised by resource-aware leveling stays numeric.
+func SetLevelOrder(levels []Level) {
+	defaultLogger.SetLevelOrder(levels)
+}
+
+func (l *Logger) SetLevelOrder(levels []Level) {
+	order := append([]Level(nil), levels...)
+	l.Transact(func(cfg *LoggerConfig) { cfg.LevelOrder = order })
+}
+
+// levelAtLeast reports whether level ranks at or above min in order.
+func levelAtLeast(order []Level, level, min Level) bool {
+	if order == nil {
+		return level >= min
+	}
+	return levelRank(order, level) >= levelRank(order, min)
+}
+
+// levelRank returns the position of level in order, or -1 if it is missing.
+func levelRank(order []Level, level Level) int {
+	for i, o := range order {
+		if o == level {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,70 @@
+package golog
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestParseLevel checks every level name in several cases and an unknown name.
+func TestParseLevel(t *testing.T) {
+	cases := map[string]Level{
+		"TRACE": LevelTrace,
+		"debug": LevelDebug,
+		"Info":  LevelInfo,
+		"WARN":  LevelWarn,
+		"error": LevelError,
+		"PaNiC": LevelPanic,
+		"fatal": LevelFatal,
+	}
+	for s, want := range cases {
+		got, err := ParseLevel(s)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q): expected %v, got %v (%v)", s, want, got, err)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+// TestLevelString checks the Stringer output and that it round-trips.
+func TestLevelString(t *testing.T) {
+	if got := fmt.Sprint(LevelWarn); got != "warn" {
+		t.Errorf("expected warn, got %q", got)
+	}
+	for level := LevelDebug; level <= LevelFatal; level++ {
+		if parsed, err := ParseLevel(level.String()); err != nil || parsed != level {
+			t.Errorf("%v did not round-trip: %v (%v)", level, parsed, err)
+		}
+	}
+}
+
+// TestSetLevelOrder checks filtering by a custom ranking, including a custom
+// level and a level missing from the list.
+func TestSetLevelOrder(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	notice := Level(10)
+	l.SetLevelOrder([]Level{LevelDebug, LevelInfo, notice, LevelWarn, LevelError})
+	l.SetLevel(notice)
+
+	l.Info("info")
+	l.Warn("warn")
+	l.log(notice, "notice")
+	l.log(LevelFatal, "unranked")
+	if got := strings.Count(buf.String(), "\n"); got != 2 {
+		t.Errorf("expected warn and notice only, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "info") || strings.Contains(buf.String(), "unranked") {
+		t.Errorf("expected info and unranked levels to be suppressed, got %q", buf.String())
+	}
+
+	buf.Reset()
+	l.SetLevelOrder(nil)
+	l.Info("info")
+	if buf.Len() != 0 {
+		t.Errorf("expected numeric order to suppress info below level 10, got %q", buf.String())
+	}
+}
@@ -0,0 +1,57 @@
+package golog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// HTTPHandler is Logger.HTTPHandler for the default logger.
+func HTTPHandler() http.Handler {
+	return defaultLogger.HTTPHandler()
+}
+
+// HTTPHandler returns a handler, meant to be mounted at /loglevel, that lets
+// operators read and change l's level at runtime. GET responds with the
+// current level as a JSON string, e.g. "info". PUT with a body such as
+// {"level":"debug"} sets the level and responds with the new one; unknown
+// levels get 400 Bad Request. The body may also, or instead, carry a
+// "sampling_rate" between 0 and 1, which is passed to SetSamplingRate.
+// Children derived from l keep their own level but share the sampling rate.
+func (l *Logger) HTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var body struct {
+				Level        string   `json:"level"`
+				SamplingRate *float64 `json:"sampling_rate"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, fmt.Sprintf("invalid body, want {\"level\":\"<name>\"}: %v", err), http.StatusBadRequest)
+				return
+			}
+			if rate := body.SamplingRate; rate != nil && (*rate < 0 || *rate > 1) {
+				http.Error(w, fmt.Sprintf("sampling_rate %v is not between 0 and 1", *rate), http.StatusBadRequest)
+				return
+			}
+			if body.Level != "" || body.SamplingRate == nil {
+				level, err := ParseLevel(body.Level)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				l.SetLevel(level)
+			}
+			if body.SamplingRate != nil {
+				l.SetSamplingRate(*body.SamplingRate)
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(l.GetLevel().String())
+	})
+}
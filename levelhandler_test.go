@@ -0,0 +1,52 @@
+package golog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestHTTPHandler checks reading, changing and rejecting levels.
+func TestHTTPHandler(t *testing.T) {
+	l := NewLogger()
+	h := l.HTTPHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "\"info\"\n" {
+		t.Errorf("GET: unexpected response %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug"}`)))
+	if rec.Code != http.StatusOK || rec.Body.String() != "\"debug\"\n" || l.GetLevel() != LevelDebug {
+		t.Errorf("PUT: unexpected response %d %q, level %v", rec.Code, rec.Body.String(), l.GetLevel())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"sampling_rate":0.25}`)))
+	if rec.Code != http.StatusOK || l.GetLevel() != LevelDebug || l.SamplingRate() != 0.25 {
+		t.Errorf("PUT sampling_rate: unexpected response %d, level %v, rate %v", rec.Code, l.GetLevel(), l.SamplingRate())
+	}
+
+	for _, body := range []string{`{"level":"loud"}`, `{"level":"warn","sampling_rate":2}`, `debug`} {
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(body)))
+		if rec.Code != http.StatusBadRequest || l.GetLevel() != LevelDebug {
+			t.Errorf("PUT %s: expected 400 and an unchanged level, got %d %v", body, rec.Code, l.GetLevel())
+		}
+		if l.SamplingRate() != 0.25 {
+			t.Errorf("PUT %s: expected an unchanged sampling rate, got %v", body, l.SamplingRate())
+		}
+	}
+	if !strings.Contains(rec.Body.String(), "invalid body") {
+		t.Errorf("expected a descriptive message, got %q", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/loglevel", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package golog
+
+import (
+	"io"
+	"os"
+)
+
+// numLevels is the number of built-in levels, LevelTrace through LevelFatal.
+const numLevels = int(LevelFatal) + 1
+
+// stdout is the console output NewLoggerSplit uses below LevelError.
+var stdout = NewSafeWriter(os.Stdout)
+
+// NewLoggerSplit returns a logger that writes Error, Panic and Fatal lines
+// to stderr and everything else to stdout, for environments that collect
+// the two streams separately. Options apply afterwards, so WithOutput
+// replaces the stdout writer only.
+func NewLoggerSplit(opts ...Option) *Logger {
+	l := NewLogger(WithOutput(stdout))
+	for _, level := range []Level{LevelError, LevelPanic, LevelFatal} {
+		l.levelWriters[level] = stderr
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// SetLevelOutput overrides the console writer of the default logger for
+// level. See Logger.SetLevelOutput.
+func SetLevelOutput(level Level, w io.Writer) {
+	defaultLogger.SetLevelOutput(level, w)
+}
+
+// SetLevelOutput sends console lines at level to w instead of the writer
+// set by SetOutput. A nil w removes the override. Writer middleware wraps
+// only the shared writer; levels other than the built-in ones cannot be
+// overridden.
+func (l *Logger) SetLevelOutput(level Level, w io.Writer) {
+	if level < 0 || int(level) >= numLevels {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.levelWriters[level] = w
+}
+
+func (l *Logger) levelWriter(level Level) io.Writer {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.consoleWriter(level)
+}
+
+func (l *Logger) levelWriterList() [numLevels]io.Writer {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.levelWriters
+}
+
+// consoleWriter returns the writer for lines at level. The caller must hold
+// mutex.
+func (l *Logger) consoleWriter(level Level) io.Writer {
+	if level >= 0 && int(level) < numLevels && l.levelWriters[level] != nil {
+		return l.levelWriters[level]
+	}
+	return l.w
+}
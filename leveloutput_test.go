@@ -0,0 +1,44 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSetLevelOutput checks that overridden levels go to their own writer
+// and the rest to the shared one, also in clones.
+func TestSetLevelOutput(t *testing.T) {
+	var out, errs bytes.Buffer
+	l := NewLogger(WithOutput(&out))
+	l.SetLevelOutput(LevelError, &errs)
+	l.Info("served")
+	l.Error("failed")
+	l.Named("db").Error("lost connection")
+
+	if expected := "[INFO] served \n"; out.String() != expected {
+		t.Errorf("expected %q on the shared writer, got %q", expected, out.String())
+	}
+	if expected := "[ERROR] failed \n[ERROR] [db] lost connection \n"; errs.String() != expected {
+		t.Errorf("expected %q on the error writer, got %q", expected, errs.String())
+	}
+
+	l.SetLevelOutput(LevelError, nil)
+	l.Error("back")
+	if expected := "[INFO] served \n[ERROR] back \n"; out.String() != expected {
+		t.Errorf("expected %q after removing the override, got %q", expected, out.String())
+	}
+}
+
+// TestNewLoggerSplit checks the default writers of a split logger.
+func TestNewLoggerSplit(t *testing.T) {
+	l := NewLoggerSplit()
+	for level := LevelTrace; level <= LevelFatal; level++ {
+		want := stdout
+		if level >= LevelError {
+			want = stderr
+		}
+		if got := l.levelWriter(level); got != want {
+			t.Errorf("%v: unexpected writer %v", level, got)
+		}
+	}
+}
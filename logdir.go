@@ -0,0 +1,45 @@
+package golog
+
+// DefaultFilePattern names log files by the hour, e.g. 2006-01-02_15.log.
+const DefaultFilePattern = "2006-01-02_15.log"
+
+func SetLogDir(dir string) {
+	defaultLogger.SetLogDir(dir)
+}
+
+func SetFilePattern(pattern string) {
+	defaultLogger.SetFilePattern(pattern)
+}
+
+// SetLogDir sets the directory log files are written to, created on demand.
+// The default is "log" in the working directory. It takes effect from the
+// next file opened.
+func (l *Logger) SetLogDir(dir string) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.logDir = dir
+}
+
+// SetFilePattern sets the time.Format layout log files are named with. A new
+// file, and so a rotation, starts whenever the formatted name changes: a
+// pattern such as "app_2006-01-02.log" gives daily files. Empty restores
+// DefaultFilePattern.
+func (l *Logger) SetFilePattern(pattern string) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.filePattern = pattern
+}
+
+func (l *Logger) logDirOrDefault() string {
+	if l.logDir == "" {
+		return "log"
+	}
+	return l.logDir
+}
+
+func (l *Logger) filePatternOrDefault() string {
+	if l.filePattern == "" {
+		return DefaultFilePattern
+	}
+	return l.filePattern
+}
@@ -0,0 +1,48 @@
+package golog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestSetLogDir checks that files land in the configured directory under the pattern's name.
+func TestSetLogDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "logs")
+	l := NewLogger()
+	l.SetLogDir(dir)
+	l.SetFilePattern("app_2006-01-02.log")
+	l.writeToFile("[INFO] daily\n")
+	l.closeLogFile()
+
+	path := filepath.Join(dir, time.Now().Format("app_2006-01-02.log"))
+	if got, err := os.ReadFile(path); err != nil || string(got) != "[INFO] daily\n" {
+		t.Errorf("expected the entry in %s, got %q (%v)", path, got, err)
+	}
+}
+
+// TestSetFilePattern checks that a pattern change switches files on the next write.
+func TestSetFilePattern(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLogger()
+	l.SetLogDir(dir)
+	l.SetFilePattern("2006-01-02_15-04-05.000.log")
+	rotated := make(chan string, 1)
+	l.SetRotationCallback(func(path string) { rotated <- path })
+
+	l.writeToFile("[INFO] first\n")
+	time.Sleep(2 * time.Millisecond)
+	l.writeToFile("[INFO] second\n")
+	l.closeLogFile()
+
+	select {
+	case <-rotated:
+	case <-time.After(time.Second):
+		t.Fatal("expected a finer pattern to rotate between writes")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("expected 2 files, got %d", len(entries))
+	}
+}
@@ -0,0 +1,96 @@
+package golog
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// LogError logs err with a stack trace at LevelError on the default logger.
+// See Logger.LogError.
+func LogError(err error) {
+	if err == nil || !defaultLogger.accepts(defaultLogger.settings(), capLevel(LevelError)) {
+		return
+	}
+	defaultLogger.log(LevelError, escapeFormat(errorWithStack(err, debug.Stack())))
+}
+
+// LogError logs err at LevelError followed by a stack trace, one indented
+// frame per line. If err, or an error it wraps, has a StackTrace method
+// like those of github.com/pkg/errors, the trace of the innermost one is
+// used; otherwise it is the stack of the calling goroutine. A nil err logs
+// nothing.
+func (l *Logger) LogError(err error) {
+	if err == nil || !l.accepts(l.settings(), capLevel(LevelError)) {
+		return
+	}
+	l.log(LevelError, escapeFormat(errorWithStack(err, debug.Stack())))
+}
+
+// errorWithStack renders err and the stack it carries, or else current, the
+// output of debug.Stack taken in LogError.
+func errorWithStack(err error, current []byte) string {
+	var b strings.Builder
+	b.WriteString(err.Error())
+	if pcs := errorStack(err); pcs != nil {
+		frames := runtime.CallersFrames(pcs)
+		for {
+			frame, more := frames.Next()
+			fmt.Fprintf(&b, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+			if !more {
+				break
+			}
+		}
+		return b.String()
+	}
+	for _, line := range callerStack(current) {
+		b.WriteString("\n\t")
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// errorStack returns the program counters recorded by the innermost error
+// in err's chain with a StackTrace method returning a slice of uintptr-based
+// frames, or nil if there is none. Reflection keeps github.com/pkg/errors
+// out of the dependencies.
+func errorStack(err error) []uintptr {
+	var pcs []uintptr
+	for ; err != nil; err = errors.Unwrap(err) {
+		method := reflect.ValueOf(err).MethodByName("StackTrace")
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			continue
+		}
+		trace := method.Call(nil)[0]
+		if trace.Kind() != reflect.Slice || trace.Type().Elem().Kind() != reflect.Uintptr {
+			continue
+		}
+		pcs = make([]uintptr, trace.Len())
+		for i := range pcs {
+			pcs[i] = uintptr(trace.Index(i).Uint())
+		}
+	}
+	return pcs
+}
+
+// callerStack returns the lines of stack, the output of debug.Stack, below
+// the LogError frame, dropping the goroutine header and golog's own frames.
+func callerStack(stack []byte) []string {
+	lines := strings.Split(strings.TrimRight(string(stack), "\n"), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "github.com/ryqdev/golog.LogError(") ||
+			strings.HasPrefix(line, "github.com/ryqdev/golog.(*Logger).LogError(") {
+			if i+2 <= len(lines) {
+				return lines[i+2:]
+			}
+			break
+		}
+	}
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "goroutine ") {
+		return lines[1:]
+	}
+	return lines
+}
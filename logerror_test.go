@@ -0,0 +1,65 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// stackFrame and stackTrace mirror the types of github.com/pkg/errors.
+type stackFrame uintptr
+
+type stackTrace []stackFrame
+
+type tracedError struct {
+	msg   string
+	stack stackTrace
+}
+
+func newTracedError(msg string) error {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	err := &tracedError{msg: msg}
+	for _, pc := range pcs[:n] {
+		err.stack = append(err.stack, stackFrame(pc))
+	}
+	return err
+}
+
+func (e *tracedError) Error() string          { return e.msg }
+func (e *tracedError) StackTrace() stackTrace { return e.stack }
+
+func tracedOrigin() error { return newTracedError("disk full") }
+
+// TestLogError checks the message line and indented frames for errors with
+// and without their own stack trace.
+func TestLogError(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.LogError(fmt.Errorf("save: %w", tracedOrigin()))
+	out := buf.String()
+	if !strings.HasPrefix(out, "[ERROR] save: disk full\n\tgithub.com/ryqdev/golog.tracedOrigin\n\t\t") {
+		t.Errorf("expected the carried stack to start at its origin, got %q", out)
+	}
+
+	buf.Reset()
+	l.LogError(errors.New("plain 100%"))
+	out = buf.String()
+	if !strings.HasPrefix(out, "[ERROR] plain 100%\n\tgithub.com/ryqdev/golog.TestLogError(") {
+		t.Errorf("expected the caller's stack without golog frames, got %q", out)
+	}
+	if strings.Contains(out, "runtime/debug") {
+		t.Errorf("expected debug.Stack's own frame to be dropped, got %q", out)
+	}
+
+	buf.Reset()
+	l.LogError(nil)
+	l.SetLevel(LevelFatal)
+	l.LogError(errors.New("hidden"))
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing to be logged, got %q", buf.String())
+	}
+}
@@ -0,0 +1,83 @@
+package logparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+// FilterOption is a predicate an entry must satisfy to be kept by Filter.
+type FilterOption func(Entry) bool
+
+// Filter returns the entries matching every option.
+func Filter(entries []Entry, opts ...FilterOption) []Entry {
+	var kept []Entry
+	for _, entry := range entries {
+		if matchesAll(entry, opts) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+func matchesAll(entry Entry, opts []FilterOption) bool {
+	for _, opt := range opts {
+		if !opt(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// ByLevel keeps entries with min <= level <= max.
+func ByLevel(min, max golog.Level) FilterOption {
+	return func(e Entry) bool {
+		return e.Level >= min && e.Level <= max
+	}
+}
+
+// ByTimeRange keeps entries logged between from and to, inclusive.
+func ByTimeRange(from, to time.Time) FilterOption {
+	return func(e Entry) bool {
+		return !e.Time.Before(from) && !e.Time.After(to)
+	}
+}
+
+// ByFieldValue keeps entries whose field key formats as value.
+func ByFieldValue(key, value string) FilterOption {
+	return func(e Entry) bool {
+		v, ok := e.Fields[key]
+		return ok && fmt.Sprint(v) == value
+	}
+}
+
+// ByMessageContains keeps entries whose message contains substr.
+func ByMessageContains(substr string) FilterOption {
+	return func(e Entry) bool {
+		return strings.Contains(e.Message, substr)
+	}
+}
+
+// Summary counts entries by level and by the minute they were logged in.
+type Summary struct {
+	Total     int
+	ByLevel   map[golog.Level]int
+	PerMinute map[time.Time]int
+}
+
+func Summarize(entries []Entry) Summary {
+	summary := Summary{
+		Total:     len(entries),
+		ByLevel:   make(map[golog.Level]int),
+		PerMinute: make(map[time.Time]int),
+	}
+	for _, entry := range entries {
+		summary.ByLevel[entry.Level]++
+		if !entry.Time.IsZero() {
+			summary.PerMinute[entry.Time.Truncate(time.Minute)]++
+		}
+	}
+	return summary
+}
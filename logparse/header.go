@@ -0,0 +1,95 @@
+package logparse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ryqdev/golog"
+)
+
+// ErrNoHeader is returned by ReadFileHeader for files without a header line.
+var ErrNoHeader = errors.New("logparse: no file header")
+
+// FileHeader is the parsed header line written by golog's
+// SetFileHeaderVersion.
+type FileHeader struct {
+	Version  int
+	Format   string            // "text" or "jsonl"
+	Rotation string            // e.g. "hourly"
+	Extra    map[string]string // Keys this version of logparse does not know
+}
+
+// ReadFileHeader reads only the first line of path and parses it as a file
+// header.
+func ReadFileHeader(path string) (FileHeader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return FileHeader{}, err
+	}
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return FileHeader{}, err
+	}
+	return parseHeader(strings.TrimRight(line, "\r\n"))
+}
+
+// ParseFile parses path with ParseJSONL or ParseTextLog (using
+// DefaultTimeLayout) according to its header. Files without a header are
+// assumed to be text.
+func ParseFile(path string) ([]Entry, error) {
+	header, err := ReadFileHeader(path)
+	if err != nil && !errors.Is(err, ErrNoHeader) {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if header.Format == "jsonl" {
+		return ParseJSONL(file)
+	}
+	return ParseTextLog(file, DefaultTimeLayout)
+}
+
+func isHeader(line string) bool {
+	return strings.HasPrefix(line, golog.FileHeaderPrefix)
+}
+
+func parseHeader(line string) (FileHeader, error) {
+	if !isHeader(line) {
+		return FileHeader{}, ErrNoHeader
+	}
+	tokens := strings.Fields(strings.TrimPrefix(line, golog.FileHeaderPrefix))
+	if len(tokens) == 0 || !strings.HasPrefix(tokens[0], "v") {
+		return FileHeader{}, fmt.Errorf("logparse: malformed file header %q", line)
+	}
+	version, err := strconv.Atoi(tokens[0][1:])
+	if err != nil {
+		return FileHeader{}, fmt.Errorf("logparse: malformed file header version %q", tokens[0])
+	}
+
+	header := FileHeader{Version: version}
+	for _, token := range tokens[1:] {
+		key, value, _ := strings.Cut(token, "=")
+		switch key {
+		case "format":
+			header.Format = value
+		case "rotation":
+			header.Rotation = value
+		default:
+			if header.Extra == nil {
+				header.Extra = make(map[string]string)
+			}
+			header.Extra[key] = value
+		}
+	}
+	return header, nil
+}
@@ -0,0 +1,41 @@
+package logparse
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryqdev/golog"
+)
+
+// TestReadFileHeader checks header parsing, including unknown keys.
+func TestReadFileHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	os.WriteFile(path, []byte("# golog v1 format=jsonl rotation=hourly host=web1\n{\"level\":\"INFO\",\"msg\":\"hi\"}\n"), 0644)
+
+	header, err := ReadFileHeader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Version != 1 || header.Format != "jsonl" || header.Rotation != "hourly" || header.Extra["host"] != "web1" {
+		t.Errorf("unexpected header %+v", header)
+	}
+
+	entries, err := ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Level != golog.LevelInfo || entries[0].Message != "hi" {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+}
+
+// TestReadFileHeaderMissing checks that files without a header report ErrNoHeader.
+func TestReadFileHeaderMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	os.WriteFile(path, []byte("[INFO] no header\n"), 0644)
+	if _, err := ReadFileHeader(path); !errors.Is(err, ErrNoHeader) {
+		t.Errorf("expected ErrNoHeader, got %v", err)
+	}
+}
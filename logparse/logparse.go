@@ -0,0 +1,121 @@
+// Package logparse reads log files written by golog back into entries for
+// offline analysis.
+package logparse
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+// Entry is a single parsed log line.
+type Entry struct {
+	Level   golog.Level
+	Time    time.Time
+	Prefix  string // Set by Named or SetPrefix
+	Caller  string
+	Message string
+	Fields  map[string]any // Keys other than level, ts, file, prefix and msg
+}
+
+// ParseJSONL reads a log file written in golog.FileFormatJSONL mode. Blank
+// lines and a leading file header are skipped; the first malformed line
+// stops parsing with an error that carries its line number.
+func ParseJSONL(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || lineNo == 1 && isHeader(line) {
+			continue
+		}
+		entry, err := parseJSONLine(line)
+		if err != nil {
+			return entries, fmt.Errorf("logparse: line %d: %w", lineNo, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+func parseJSONLine(line string) (Entry, error) {
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(line), &raw); err != nil {
+		return Entry{}, err
+	}
+
+	var entry Entry
+	name, _ := raw["level"].(string)
+	level, ok := parseLevel(name)
+	if !ok {
+		return Entry{}, fmt.Errorf("unknown level %q", name)
+	}
+	entry.Level = level
+	if ts, _ := raw["ts"].(string); ts != "" {
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.Time = t
+	}
+	entry.Caller, _ = raw["file"].(string)
+	entry.Prefix, _ = raw["prefix"].(string)
+	entry.Message, _ = raw["msg"].(string)
+
+	for _, key := range []string{"level", "ts", "file", "prefix", "msg"} {
+		delete(raw, key)
+	}
+	if len(raw) > 0 {
+		entry.Fields = raw
+	}
+	return entry, nil
+}
+
+// parseLevel maps the upper-case names golog writes to their levels.
+func parseLevel(name string) (golog.Level, bool) {
+	switch name {
+	case "TRACE":
+		return golog.LevelTrace, true
+	case "DEBUG":
+		return golog.LevelDebug, true
+	case "INFO":
+		return golog.LevelInfo, true
+	case "WARN":
+		return golog.LevelWarn, true
+	case "ERROR":
+		return golog.LevelError, true
+	case "PANIC":
+		return golog.LevelPanic, true
+	case "FATAL":
+		return golog.LevelFatal, true
+	}
+	return 0, false
+}
+
+// levelName is the inverse of parseLevel.
+func levelName(level golog.Level) string {
+	switch level {
+	case golog.LevelTrace:
+		return "TRACE"
+	case golog.LevelDebug:
+		return "DEBUG"
+	case golog.LevelInfo:
+		return "INFO"
+	case golog.LevelWarn:
+		return "WARN"
+	case golog.LevelError:
+		return "ERROR"
+	case golog.LevelPanic:
+		return "PANIC"
+	case golog.LevelFatal:
+		return "FATAL"
+	}
+	return fmt.Sprintf("LEVEL(%d)", level)
+}
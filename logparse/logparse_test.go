@@ -0,0 +1,77 @@
+package logparse
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+const sampleJSONL = `{"level":"INFO","ts":"2024-09-17T12:44:31.1Z","file":"main.go:10","msg":"server started","port":"8080"}
+{"level":"DEBUG","ts":"2024-09-17T12:44:45Z","msg":"cache warm"}
+
+{"level":"ERROR","ts":"2024-09-17T12:45:02Z","file":"db.go:42","msg":"query failed","port":"5432"}
+`
+
+// TestParseJSONL checks that each line becomes an entry with extra keys kept as fields.
+func TestParseJSONL(t *testing.T) {
+	entries, err := ParseJSONL(strings.NewReader(sampleJSONL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	first := entries[0]
+	if first.Level != golog.LevelInfo || first.Caller != "main.go:10" || first.Message != "server started" {
+		t.Errorf("unexpected entry %+v", first)
+	}
+	if first.Fields["port"] != "8080" {
+		t.Errorf("expected port field, got %v", first.Fields)
+	}
+}
+
+// TestParseJSONLError checks that a malformed line reports its line number.
+func TestParseJSONLError(t *testing.T) {
+	_, err := ParseJSONL(strings.NewReader("{\"level\":\"INFO\",\"msg\":\"ok\"}\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected line 2 error, got %v", err)
+	}
+}
+
+// TestFilter checks each filter option and their combination.
+func TestFilter(t *testing.T) {
+	entries, _ := ParseJSONL(strings.NewReader(sampleJSONL))
+	from := time.Date(2024, 9, 17, 12, 44, 40, 0, time.UTC)
+	to := time.Date(2024, 9, 17, 12, 46, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		opts []FilterOption
+		want int
+	}{
+		"level":    {[]FilterOption{ByLevel(golog.LevelInfo, golog.LevelError)}, 2},
+		"time":     {[]FilterOption{ByTimeRange(from, to)}, 2},
+		"field":    {[]FilterOption{ByFieldValue("port", "5432")}, 1},
+		"message":  {[]FilterOption{ByMessageContains("cache")}, 1},
+		"combined": {[]FilterOption{ByTimeRange(from, to), ByLevel(golog.LevelError, golog.LevelError)}, 1},
+	}
+	for name, c := range cases {
+		if got := len(Filter(entries, c.opts...)); got != c.want {
+			t.Errorf("%s: expected %d entries, got %d", name, c.want, got)
+		}
+	}
+}
+
+// TestSummarize checks counts by level and minute bucket.
+func TestSummarize(t *testing.T) {
+	entries, _ := ParseJSONL(strings.NewReader(sampleJSONL))
+	summary := Summarize(entries)
+
+	if summary.Total != 3 || summary.ByLevel[golog.LevelError] != 1 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if got := summary.PerMinute[time.Date(2024, 9, 17, 12, 44, 0, 0, time.UTC)]; got != 2 {
+		t.Errorf("expected 2 entries in 12:44, got %d", got)
+	}
+}
@@ -0,0 +1,164 @@
+package logparse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+const topMessagesLimit = 10
+
+// ReportFormat selects the encoding used by Report.Render.
+type ReportFormat int
+
+const (
+	ReportText ReportFormat = iota
+	ReportJSON
+)
+
+// MessageCount is a message and how often it occurred.
+type MessageCount struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// Report aggregates entries from one or more log files.
+type Report struct {
+	TotalEntries       int
+	EntriesByLevel     map[golog.Level]int
+	TopMessages        []MessageCount // Most frequent first, at most 10
+	TimeSeriesByMinute map[time.Time]int
+	UniqueCallers      []string // Sorted
+}
+
+// Aggregate parses every file in paths with parser and combines the results.
+// Files that fail to open or parse cleanly still contribute the entries that
+// were read; their errors are joined into the returned error.
+func Aggregate(paths []string, parser func(io.Reader) ([]Entry, error)) (*Report, error) {
+	var (
+		entries []Entry
+		errs    []error
+	)
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		parsed, err := parser(file)
+		file.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+		entries = append(entries, parsed...)
+	}
+	return buildReport(entries), errors.Join(errs...)
+}
+
+func buildReport(entries []Entry) *Report {
+	report := &Report{
+		TotalEntries:       len(entries),
+		EntriesByLevel:     make(map[golog.Level]int),
+		TimeSeriesByMinute: make(map[time.Time]int),
+	}
+	messages := make(map[string]int)
+	callers := make(map[string]bool)
+	for _, entry := range entries {
+		report.EntriesByLevel[entry.Level]++
+		messages[entry.Message]++
+		if !entry.Time.IsZero() {
+			report.TimeSeriesByMinute[entry.Time.Truncate(time.Minute)]++
+		}
+		if entry.Caller != "" {
+			callers[entry.Caller] = true
+		}
+	}
+
+	for message, count := range messages {
+		report.TopMessages = append(report.TopMessages, MessageCount{Message: message, Count: count})
+	}
+	sort.Slice(report.TopMessages, func(i, j int) bool {
+		a, b := report.TopMessages[i], report.TopMessages[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Message < b.Message
+	})
+	if len(report.TopMessages) > topMessagesLimit {
+		report.TopMessages = report.TopMessages[:topMessagesLimit]
+	}
+
+	for caller := range callers {
+		report.UniqueCallers = append(report.UniqueCallers, caller)
+	}
+	sort.Strings(report.UniqueCallers)
+	return report
+}
+
+// Render writes the report to w in the given format.
+func (r *Report) Render(w io.Writer, format ReportFormat) error {
+	switch format {
+	case ReportText:
+		return r.renderText(w)
+	case ReportJSON:
+		return r.renderJSON(w)
+	}
+	return fmt.Errorf("logparse: unknown report format %d", format)
+}
+
+func (r *Report) renderText(w io.Writer) error {
+	fmt.Fprintf(w, "Total entries: %d\n", r.TotalEntries)
+	fmt.Fprintln(w, "Entries by level:")
+	for _, level := range sortedLevels(r.EntriesByLevel) {
+		fmt.Fprintf(w, "  %-6s %d\n", levelName(level), r.EntriesByLevel[level])
+	}
+	fmt.Fprintln(w, "Top messages:")
+	for _, m := range r.TopMessages {
+		fmt.Fprintf(w, "  %6d  %s\n", m.Count, m.Message)
+	}
+	fmt.Fprintln(w, "Entries per minute:")
+	minutes := make([]time.Time, 0, len(r.TimeSeriesByMinute))
+	for minute := range r.TimeSeriesByMinute {
+		minutes = append(minutes, minute)
+	}
+	sort.Slice(minutes, func(i, j int) bool { return minutes[i].Before(minutes[j]) })
+	for _, minute := range minutes {
+		fmt.Fprintf(w, "  %s %d\n", minute.Format(time.RFC3339), r.TimeSeriesByMinute[minute])
+	}
+	_, err := fmt.Fprintf(w, "Unique callers: %d\n", len(r.UniqueCallers))
+	for _, caller := range r.UniqueCallers {
+		_, err = fmt.Fprintf(w, "  %s\n", caller)
+	}
+	return err
+}
+
+func (r *Report) renderJSON(w io.Writer) error {
+	byLevel := make(map[string]int, len(r.EntriesByLevel))
+	for level, count := range r.EntriesByLevel {
+		byLevel[levelName(level)] = count
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		TotalEntries       int               `json:"total_entries"`
+		EntriesByLevel     map[string]int    `json:"entries_by_level"`
+		TopMessages        []MessageCount    `json:"top_messages"`
+		TimeSeriesByMinute map[time.Time]int `json:"time_series_by_minute"`
+		UniqueCallers      []string          `json:"unique_callers"`
+	}{r.TotalEntries, byLevel, r.TopMessages, r.TimeSeriesByMinute, r.UniqueCallers})
+}
+
+func sortedLevels(counts map[golog.Level]int) []golog.Level {
+	levels := make([]golog.Level, 0, len(counts))
+	for level := range counts {
+		levels = append(levels, level)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+	return levels
+}
@@ -0,0 +1,75 @@
+package logparse
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ryqdev/golog"
+)
+
+// TestAggregate checks totals, top messages and callers across several files.
+func TestAggregate(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a.log")
+	second := filepath.Join(dir, "b.log")
+	os.WriteFile(first, []byte(sampleJSONL), 0644)
+	os.WriteFile(second, []byte(`{"level":"ERROR","ts":"2024-09-17T12:45:30Z","file":"db.go:42","msg":"query failed"}`+"\n"), 0644)
+
+	report, err := Aggregate([]string{first, second}, ParseJSONL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.TotalEntries != 4 || report.EntriesByLevel[golog.LevelError] != 2 {
+		t.Errorf("unexpected totals %+v", report)
+	}
+	if report.TopMessages[0] != (MessageCount{Message: "query failed", Count: 2}) {
+		t.Errorf("unexpected top message %+v", report.TopMessages[0])
+	}
+	if strings.Join(report.UniqueCallers, ",") != "db.go:42,main.go:10" {
+		t.Errorf("unexpected callers %v", report.UniqueCallers)
+	}
+}
+
+// TestAggregateMissingFile checks that unreadable files are reported but do not discard other results.
+func TestAggregateMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.log")
+	os.WriteFile(path, []byte(sampleJSONL), 0644)
+
+	report, err := Aggregate([]string{path, filepath.Join(t.TempDir(), "missing.log")}, ParseJSONL)
+	if err == nil {
+		t.Error("expected error for missing file")
+	}
+	if report.TotalEntries != 3 {
+		t.Errorf("expected entries from readable file, got %d", report.TotalEntries)
+	}
+}
+
+// TestReportRender checks both output formats.
+func TestReportRender(t *testing.T) {
+	entries, _ := ParseJSONL(strings.NewReader(sampleJSONL))
+	report := buildReport(entries)
+
+	var text bytes.Buffer
+	if err := report.Render(&text, ReportText); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text.String(), "Total entries: 3") || !strings.Contains(text.String(), "ERROR") {
+		t.Errorf("unexpected text report:\n%s", text.String())
+	}
+
+	var out bytes.Buffer
+	if err := report.Render(&out, ReportJSON); err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON report: %v", err)
+	}
+	if decoded["total_entries"] != float64(3) {
+		t.Errorf("unexpected JSON report %v", decoded)
+	}
+}
@@ -0,0 +1,152 @@
+package logparse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+// DefaultTimeLayout matches the timestamps golog writes when showDetail is
+// on and no other layout has been set with SetTimeFormat.
+const DefaultTimeLayout = golog.DefaultTimeFormat
+
+// LegacyTimeLayout matches the time.Time.String timestamps of older golog
+// versions.
+const LegacyTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// ParseError describes a line ParseTextLog could not parse.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("logparse: line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseTextLog reads lines of the form
+// "[LEVEL] [<prefix>] <timestamp> <caller> <message>" as written by the
+// default text format with showDetail on; the prefix set by Named or
+// SetPrefix is optional and goes to Entry.Prefix. layout is the
+// time format of the timestamp. Lines that do not start with a level tag are
+// continuations of the previous message, except for a leading file header,
+// which is skipped. Malformed lines are skipped and
+// reported as *ParseError values joined into the returned error.
+func ParseTextLog(r io.Reader, layout string) ([]Entry, error) {
+	var (
+		entries []Entry
+		errs    []error
+	)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if lineNo == 1 && isHeader(line) {
+			continue
+		}
+		if !hasLevelTag(line) {
+			if len(entries) == 0 {
+				errs = append(errs, &ParseError{Line: lineNo, Err: errors.New("continuation without a preceding entry")})
+				continue
+			}
+			last := &entries[len(entries)-1]
+			last.Message = strings.TrimSuffix(last.Message+"\n"+line, " ")
+			continue
+		}
+		entry, err := parseTextLine(line, layout)
+		if err != nil {
+			errs = append(errs, &ParseError{Line: lineNo, Err: err})
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return entries, errors.Join(errs...)
+}
+
+func hasLevelTag(line string) bool {
+	if !strings.HasPrefix(line, "[") {
+		return false
+	}
+	end := strings.IndexByte(line, ']')
+	if end < 0 {
+		return false
+	}
+	_, ok := parseLevel(line[1:end])
+	return ok
+}
+
+func parseTextLine(line, layout string) (Entry, error) {
+	end := strings.IndexByte(line, ']')
+	level, _ := parseLevel(line[1:end])
+
+	// The timestamp may contain spaces, so locate the caller token
+	// ("file.go:12") and treat everything before it as the timestamp.
+	tokens := strings.Split(strings.TrimSpace(line[end+1:]), " ")
+	callerIdx := -1
+	for i, token := range tokens {
+		if isCaller(token) {
+			callerIdx = i
+			break
+		}
+	}
+	if callerIdx <= 0 {
+		return Entry{}, errors.New("missing timestamp or caller")
+	}
+
+	var tsTokens []string
+	for _, token := range tokens[:callerIdx] {
+		// Drop the monotonic clock reading time.Time.String appends.
+		if !strings.HasPrefix(token, "m=") {
+			tsTokens = append(tsTokens, token)
+		}
+	}
+	// A Named or SetPrefix prefix comes before the timestamp and may itself
+	// contain spaces, so take the longest run of trailing tokens that parses.
+	var (
+		ts     time.Time
+		prefix string
+		err    error
+	)
+	for i := range tsTokens {
+		ts, err = time.Parse(layout, strings.Join(tsTokens[i:], " "))
+		if err == nil {
+			prefix = strings.Join(tsTokens[:i], " ")
+			break
+		}
+	}
+	if err != nil {
+		return Entry{}, err
+	}
+
+	return Entry{
+		Level:   level,
+		Time:    ts,
+		Prefix:  prefix,
+		Caller:  tokens[callerIdx],
+		Message: strings.Join(tokens[callerIdx+1:], " "),
+	}, nil
+}
+
+// isCaller reports whether token looks like "name.go:123".
+func isCaller(token string) bool {
+	i := strings.LastIndexByte(token, ':')
+	if i <= 0 || !strings.HasSuffix(token[:i], ".go") {
+		return false
+	}
+	_, err := strconv.Atoi(token[i+1:])
+	return err == nil
+}
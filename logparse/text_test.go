@@ -0,0 +1,106 @@
+package logparse
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+// TestParseTextLogRoundTrip checks that a file written by a logger parses back.
+func TestParseTextLogRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	l, err := golog.NewFileOnlyLogger(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l.SetLevel(golog.LevelDebug)
+	l.SetShowDetail(true)
+	l.Info("service started")
+	l.Error("request failed\nstatus=500")
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.log"))
+	if len(paths) != 1 {
+		t.Fatalf("expected one log file, got %v", paths)
+	}
+	content, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ParseTextLog(strings.NewReader(string(content)), DefaultTimeLayout)
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, content)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Level != golog.LevelInfo || entries[0].Message != "service started" {
+		t.Errorf("unexpected first entry %+v", entries[0])
+	}
+	if entries[1].Message != "request failed\nstatus=500" {
+		t.Errorf("expected multi-line message, got %q", entries[1].Message)
+	}
+	if !strings.HasPrefix(entries[0].Caller, "text_test.go:") {
+		t.Errorf("expected caller in this file, got %q", entries[0].Caller)
+	}
+	if time.Since(entries[0].Time) > time.Minute {
+		t.Errorf("unexpected timestamp %v", entries[0].Time)
+	}
+}
+
+// TestParseTextLogMalformed checks that bad lines are reported without stopping the parse.
+func TestParseTextLogMalformed(t *testing.T) {
+	input := "orphan continuation\n" +
+		"[INFO] not-a-time main.go:1 broken\n" +
+		"[INFO] 2024-09-17 12:44:31.370897 +0800 CST m=+0.000819001 main.go:93 ok \n"
+
+	entries, err := ParseTextLog(strings.NewReader(input), LegacyTimeLayout)
+	if len(entries) != 1 || entries[0].Message != "ok" {
+		t.Errorf("expected the valid line to parse, got %+v", entries)
+	}
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Line != 1 {
+		t.Errorf("expected ParseError for line 1, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected error for line 2, got %v", err)
+	}
+}
+
+// TestParseTextLogPrefix checks that lines from Named and SetPrefix loggers parse back with their prefix.
+func TestParseTextLogPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf))
+	l.SetShowDetail(true)
+	l.Named("db").Info("connected")
+	spaced := l.Named("api")
+	spaced.SetPrefix("api v2:")
+	spaced.Warn("slow request")
+
+	entries, err := ParseTextLog(strings.NewReader(buf.String()), DefaultTimeLayout)
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, buf.String())
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Prefix != "[db]" || entries[0].Message != "connected" {
+		t.Errorf("unexpected first entry %+v", entries[0])
+	}
+	if entries[1].Prefix != "api v2:" || entries[1].Message != "slow request" {
+		t.Errorf("unexpected second entry %+v", entries[1])
+	}
+	if time.Since(entries[0].Time) > time.Minute {
+		t.Errorf("unexpected timestamp %v", entries[0].Time)
+	}
+}
@@ -0,0 +1,97 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+)
+
+const (
+	// DefaultMemoryShedThreshold is the retained memory above which
+	// memory-pressure shedding starts when no threshold has been set.
+	DefaultMemoryShedThreshold = 1 << 30
+
+	memShedPollInterval = 500 * time.Millisecond
+)
+
+// SetMemoryPressureShedding starts or stops a background watcher that sheds
+// low-priority messages while the memory the process holds from the OS
+// (MemStats.Sys minus HeapReleased) is at or above the shed threshold.
+// Shed messages are discarded before they are formatted, by l and every
+// child derived from it. Unlike resource-aware leveling this only looks at
+// memory and the shed levels are configurable.
+func (l *Logger) SetMemoryPressureShedding(b bool) {
+	p := l.pressure
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if b == (p.memShedStop != nil) {
+		return
+	}
+	if b {
+		p.memShedStop = make(chan struct{})
+		go l.watchMemory(p.memShedStop)
+		return
+	}
+	close(p.memShedStop)
+	p.memShedStop = nil
+	l.setShedding(false)
+}
+
+// SetMemoryShedThreshold sets the retained memory, in bytes, at which
+// shedding starts. Zero restores DefaultMemoryShedThreshold.
+func (l *Logger) SetMemoryShedThreshold(bytes uint64) {
+	l.pressure.memShedThreshold.Store(bytes)
+}
+
+// SetShedLevel sets the highest level that is shed under memory pressure.
+// The default, LevelInfo, sheds Debug and Info.
+func (l *Logger) SetShedLevel(level Level) {
+	l.pressure.shedLevel.Store(int32(level))
+}
+
+func (l *Logger) watchMemory(stop chan struct{}) {
+	ticker := time.NewTicker(memShedPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.checkMemoryPressure()
+		}
+	}
+}
+
+func (l *Logger) checkMemoryPressure() {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	threshold := l.pressure.memShedThreshold.Load()
+	if threshold == 0 {
+		threshold = DefaultMemoryShedThreshold
+	}
+	l.setShedding(m.Sys-m.HeapReleased >= threshold)
+}
+
+// setShedding records whether messages are being shed. Transitions are
+// reported once on os.Stderr rather than through the logger to avoid
+// recursion.
+func (l *Logger) setShedding(on bool) {
+	if l.pressure.memShedding.Swap(on) == on {
+		return
+	}
+	if on {
+		fmt.Fprintln(os.Stderr, "golog: memory pressure detected, shedding low-priority messages")
+	} else {
+		fmt.Fprintln(os.Stderr, "golog: memory pressure subsided, no longer shedding messages")
+	}
+}
+
+// shed reports whether a message at level is currently being shed.
+func (l *Logger) shed(level Level) bool {
+	p := l.pressure
+	return p.memShedding.Load() && level <= Level(p.shedLevel.Load())
+}
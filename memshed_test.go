@@ -0,0 +1,63 @@
+package golog
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+// TestMemoryPressureShedding checks that Debug and Info are shed while over the threshold.
+func TestMemoryPressureShedding(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf), WithLevel(LevelDebug))
+	l.SetMemoryShedThreshold(1)
+
+	l.checkMemoryPressure()
+	l.Debug("shed")
+	l.Info("shed")
+	l.Error("kept")
+	if got, want := buf.String(), "[ERROR] kept \n"; got != want {
+		t.Errorf("expected only Error under pressure, got %q", got)
+	}
+
+	buf.Reset()
+	l.SetShedLevel(LevelDebug)
+	l.Info("kept")
+	if buf.Len() == 0 {
+		t.Error("expected Info to pass with shed level Debug")
+	}
+
+	buf.Reset()
+	l.SetMemoryShedThreshold(math.MaxUint64)
+	l.checkMemoryPressure()
+	l.Debug("restored")
+	if buf.Len() == 0 {
+		t.Error("expected Debug to pass once pressure subsided")
+	}
+}
+
+// TestSetMemoryPressureSheddingToggle checks that the watcher can be started and stopped.
+func TestSetMemoryPressureSheddingToggle(t *testing.T) {
+	l := NewLogger()
+	l.SetMemoryPressureShedding(true)
+	l.SetMemoryPressureShedding(true)
+	l.SetMemoryPressureShedding(false)
+	if l.pressure.memShedStop != nil {
+		t.Error("expected watcher to be stopped")
+	}
+}
+
+// TestMemoryShedCoversChildren checks that children shed with their parent.
+func TestMemoryShedCoversChildren(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	child := l.WithField("k", 1)
+	l.setShedding(true)
+	defer l.setShedding(false)
+
+	child.Info("shed")
+	child.Warn("kept")
+	if got, want := buf.String(), "[WARN] kept k=1 \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,56 @@
+package golog
+
+import "sort"
+
+// Wrap returns a child of l that passes every assembled entry through
+// middleware before it is written. Unlike a Processor, middleware sees the
+// formatted message, the timestamp, the caller and all typed fields, and may
+// change any of them. Wrapping a wrapped logger composes left to right: the
+// innermost middleware runs first.
+func Wrap(l *Logger, middleware func(Entry) Entry) *Logger {
+	child := l.clone()
+	child.middleware = append(append([]func(Entry) Entry(nil), l.middleware...), middleware)
+	return child
+}
+
+// applyMiddleware runs the wrapped logger's middleware over rec.
+func (l *Logger) applyMiddleware(rec record) record {
+	if len(l.middleware) == 0 {
+		return rec
+	}
+	entry := rec.entry()
+	for _, mw := range l.middleware {
+		entry = mw(entry)
+	}
+	rec.level = entry.Level
+	rec.content = entry.Message
+	rec.time = entry.Time
+	rec.file = entry.File
+	rec.line = entry.Line
+	rec.fields = mergeFieldMap(rec.fields, entry.Fields)
+	return rec
+}
+
+// mergeFieldMap turns m back into a field list, keeping the order of keys
+// that were already in fields and appending new keys sorted.
+func mergeFieldMap(fields []Field, m map[string]any) []Field {
+	merged := make([]Field, 0, len(m))
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if v, ok := m[f.Key]; ok && !seen[f.Key] {
+			merged = append(merged, Field{f.Key, v})
+			seen[f.Key] = true
+		}
+	}
+	var added []string
+	for k := range m {
+		if !seen[k] {
+			added = append(added, k)
+		}
+	}
+	sort.Strings(added)
+	for _, k := range added {
+		merged = append(merged, Field{k, m[k]})
+	}
+	return merged
+}
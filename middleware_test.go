@@ -0,0 +1,41 @@
+package golog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWrap checks that middleware can override the timestamp and inject fields, composing in order.
+func TestWrap(t *testing.T) {
+	var buf bytes.Buffer
+	fixed := time.Date(2024, 9, 17, 12, 0, 0, 0, time.UTC)
+	base := NewLogger(WithOutput(&buf), WithShowDetail(true)).WithTypedFields(Field{"svc", "api"})
+
+	l := Wrap(base, func(e Entry) Entry {
+		e.Time = fixed
+		e.Fields["ts_override"] = true
+		return e
+	})
+	l = Wrap(l, func(e Entry) Entry {
+		e.Message = strings.ToUpper(e.Message)
+		e.Fields["saw_override"] = e.Fields["ts_override"]
+		return e
+	})
+	l.Info("request served")
+
+	out := buf.String()
+	if !strings.Contains(out, " "+fixed.Format(DefaultTimeFormat)+" ") {
+		t.Errorf("expected overridden timestamp in %q", out)
+	}
+	if !strings.HasSuffix(out, " REQUEST SERVED svc=api saw_override=true ts_override=true \n") {
+		t.Errorf("expected middleware to compose left to right, got %q", out)
+	}
+
+	buf.Reset()
+	base.Info("untouched")
+	if strings.Contains(buf.String(), "ts_override") {
+		t.Errorf("expected the parent to be unaffected, got %q", buf.String())
+	}
+}
@@ -0,0 +1,108 @@
+package golog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultDedupCapacity is how many distinct messages SetDeduplication
+// tracks when no capacity is given.
+const DefaultDedupCapacity = 1024
+
+// messageDedup is the state behind SetDeduplication, shared by a logger and
+// the children cloned from it afterwards.
+type messageDedup struct {
+	window   time.Duration
+	capacity int
+	now      func() time.Time
+
+	mutex sync.Mutex
+	seen  map[dedupKey]*dedupEntry
+}
+
+// dedupEntry tracks one message since it was last written.
+type dedupEntry struct {
+	written    time.Time
+	suppressed int
+}
+
+// SetDeduplication drops messages with the same level and text as one
+// written less than window ago. The first copy written after the window
+// has passed carries a note such as "(repeated 12 times in last 1m0s)".
+// At most capacity distinct messages are tracked, DefaultDedupCapacity if
+// it is zero; when full, the oldest is forgotten. A zero window turns
+// deduplication off. Unlike NewCrossLoggerDeduplicator, it only covers l
+// and its later children. SetHashFunc makes it compare hashes instead of
+// whole messages.
+func (l *Logger) SetDeduplication(window time.Duration, capacity int) {
+	var d *messageDedup
+	if window > 0 {
+		if capacity <= 0 {
+			capacity = DefaultDedupCapacity
+		}
+		d = &messageDedup{window: window, capacity: capacity, now: time.Now, seen: make(map[dedupKey]*dedupEntry)}
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.dedup = d
+}
+
+func (l *Logger) messageDedup() *messageDedup {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.dedup
+}
+
+// deduplicate reports whether rec should be written, adding the repeat
+// count to its content when earlier copies were dropped.
+func (l *Logger) deduplicate(rec record) (record, bool) {
+	d := l.messageDedup()
+	if d == nil {
+		return rec, true
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	now := d.now()
+	key := dedupKey{level: rec.level, content: rec.content}
+	if hash := rec.cfg.HashFunc; hash != nil {
+		key = dedupKey{level: rec.level, hash: hash(rec.content)}
+	}
+	e, ok := d.seen[key]
+	if !ok {
+		if len(d.seen) >= d.capacity {
+			d.evict(now)
+		}
+		d.seen[key] = &dedupEntry{written: now}
+		return rec, true
+	}
+	if now.Sub(e.written) < d.window {
+		e.suppressed++
+		return rec, false
+	}
+	if e.suppressed > 0 {
+		rec.content += fmt.Sprintf(" (repeated %d times in last %v)", e.suppressed, d.window)
+	}
+	e.written, e.suppressed = now, 0
+	return rec, true
+}
+
+// evict forgets every message whose window has passed, or else the one
+// written longest ago. The caller must hold d.mutex.
+func (d *messageDedup) evict(now time.Time) {
+	var oldest dedupKey
+	var oldestTime time.Time
+	for key, e := range d.seen {
+		if now.Sub(e.written) >= d.window {
+			delete(d.seen, key)
+			continue
+		}
+		if oldestTime.IsZero() || e.written.Before(oldestTime) {
+			oldest, oldestTime = key, e.written
+		}
+	}
+	if len(d.seen) >= d.capacity {
+		delete(d.seen, oldest)
+	}
+}
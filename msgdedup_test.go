@@ -0,0 +1,52 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestSetDeduplication checks suppression within the window and the repeat
+// note once it has passed.
+func TestSetDeduplication(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetDeduplication(time.Minute, 0)
+	clock := time.Date(2024, 9, 17, 12, 0, 0, 0, time.UTC)
+	l.dedup.now = func() time.Time { return clock }
+
+	for i := 0; i < 4; i++ {
+		l.Error("db unreachable")
+	}
+	l.Warn("db unreachable")
+	clock = clock.Add(time.Minute)
+	l.Error("db unreachable")
+	l.Error("db unreachable")
+
+	want := "[ERROR] db unreachable \n[WARN] db unreachable \n[ERROR] db unreachable (repeated 3 times in last 1m0s) \n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+// TestSetDeduplicationCapacity checks that the oldest message is forgotten
+// once the map is full.
+func TestSetDeduplicationCapacity(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetDeduplication(time.Minute, 2)
+	clock := time.Date(2024, 9, 17, 12, 0, 0, 0, time.UTC)
+	l.dedup.now = func() time.Time { clock = clock.Add(time.Second); return clock }
+
+	l.Info("a")
+	l.Info("b")
+	l.Info("c") // Evicts a
+	l.Info("a")
+	l.Info("c")
+	if n := len(l.dedup.seen); n != 2 {
+		t.Errorf("expected 2 tracked messages, got %d", n)
+	}
+	if want := "[INFO] a \n[INFO] b \n[INFO] c \n[INFO] a \n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
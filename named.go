@@ -0,0 +1,36 @@
+package golog
+
+// Named returns a child of the default logger named name. See Logger.Named.
+func Named(name string) *Logger {
+	return defaultLogger.Named(name)
+}
+
+// Named returns a child logger whose lines carry [name] after the level tag,
+// e.g. "[INFO] [database] connected". The child starts with l's writer,
+// level and processors, but later level changes on either logger do not
+// affect the other.
+func (l *Logger) Named(name string) *Logger {
+	child := l.clone()
+	child.prefix = "[" + name + "]"
+	return child
+}
+
+// SetPrefix sets the prefix of the default logger. See Logger.SetPrefix.
+func SetPrefix(prefix string) {
+	defaultLogger.SetPrefix(prefix)
+}
+
+// SetPrefix writes prefix between the level tag and the rest of each line,
+// e.g. "[INFO] worker-3 started". It replaces the name set by Named; an
+// empty prefix removes it.
+func (l *Logger) SetPrefix(prefix string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.prefix = prefix
+}
+
+func (l *Logger) prefixText() string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.prefix
+}
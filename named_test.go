@@ -0,0 +1,50 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestNamed checks the name tag and that levels are independent after Named.
+func TestNamed(t *testing.T) {
+	var buf bytes.Buffer
+	parent := NewLogger(WithOutput(&buf))
+	db := parent.Named("database")
+	db.Info("connected to host:5432")
+
+	expected := "[INFO] [database] connected to host:5432 \n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	parent.SetLevel(LevelError)
+	db.Info("still info")
+	parent.Info("hidden")
+	if expected := "[INFO] [database] still info \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+// TestSetPrefix checks that the prefix reaches both the console and the file
+// channel, and that clearing it restores the plain format.
+func TestSetPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.setWriteLogToFile(true) // No writer goroutine: lines stay queued
+	l.SetPrefix("worker-3")
+	l.Info("started")
+	if expected := "[INFO] worker-3 started \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+	if msg := <-l.logChannel; msg.line != "[INFO] worker-3 started \n" {
+		t.Errorf("unexpected file line %q", msg.line)
+	}
+
+	buf.Reset()
+	l.SetPrefix("")
+	l.Info("started")
+	if expected := "[INFO] started \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
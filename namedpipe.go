@@ -0,0 +1,65 @@
+//go:build !windows
+
+package golog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// pipeWriter writes to a FIFO without ever blocking: with no reader attached,
+// or with the pipe full, the message is dropped.
+type pipeWriter struct {
+	path  string
+	mutex sync.Mutex
+	fd    int // -1 while no reader is attached
+}
+
+// SetNamedPipe creates a named pipe at path, unless one already exists
+// there, and sends console output to it, so tools such as jq or grep can
+// attach to a running process. Messages written while nothing reads the pipe
+// are dropped and counted in Stats.
+func (l *Logger) SetNamedPipe(path string) error {
+	if err := syscall.Mkfifo(path, 0644); err != nil {
+		if !errors.Is(err, syscall.EEXIST) {
+			return fmt.Errorf("golog: create named pipe: %w", err)
+		}
+		if info, err := os.Stat(path); err != nil || info.Mode()&os.ModeNamedPipe == 0 {
+			return fmt.Errorf("golog: %s exists and is not a named pipe", path)
+		}
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.w = &pipeWriter{path: path, fd: -1}
+	return nil
+}
+
+func (p *pipeWriter) Write(b []byte) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.fd < 0 {
+		// Opening a FIFO for writing with O_NONBLOCK fails with ENXIO while
+		// there is no reader, which is how an absent reader is detected.
+		fd, err := syscall.Open(p.path, syscall.O_WRONLY|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+		if err != nil {
+			return 0, errDropped
+		}
+		p.fd = fd
+	}
+	// The raw fd is used instead of an *os.File, which would park the
+	// goroutine in the poller until the pipe drains.
+	n, err := syscall.Write(p.fd, b)
+	if err != nil {
+		if errors.Is(err, syscall.EPIPE) {
+			// The reader went away; reopen once another attaches.
+			syscall.Close(p.fd)
+			p.fd = -1
+		}
+		return 0, errDropped
+	}
+	return n, nil
+}
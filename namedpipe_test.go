@@ -0,0 +1,50 @@
+//go:build !windows
+
+package golog
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// TestNamedPipe checks that output is dropped without a reader and delivered once one attaches.
+func TestNamedPipe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golog.pipe")
+	l := NewLogger()
+	if err := l.SetNamedPipe(path); err != nil {
+		t.Fatal(err)
+	}
+
+	l.Info("nobody listening")
+	if n := l.Stats().Dropped; n != 1 {
+		t.Errorf("expected the message to be dropped, got %d dropped", n)
+	}
+
+	reader, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	l.Info("hello pipe")
+
+	line, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line != "[INFO] hello pipe \n" {
+		t.Errorf("unexpected line from pipe %q", line)
+	}
+
+	// Reusing an existing pipe is fine; a regular file is not.
+	if err := l.SetNamedPipe(path); err != nil {
+		t.Errorf("expected existing pipe to be reused, got %v", err)
+	}
+	regular := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(regular, nil, 0644)
+	if err := l.SetNamedPipe(regular); err == nil {
+		t.Error("expected an error for a regular file")
+	}
+}
@@ -0,0 +1,25 @@
+package golog
+
+func LogOnce(level Level, key, format string, v ...any) {
+	if !defaultLogger.accepts(defaultLogger.settings(), capLevel(level)) {
+		return
+	}
+	if _, seen := defaultLogger.onceKeys.LoadOrStore(key, struct{}{}); seen {
+		return
+	}
+	defaultLogger.log(level, format, v...)
+}
+
+// LogOnce logs the message at level the first time it is called with key,
+// by l or any of its children, and ignores later calls with the same key,
+// e.g. for a deprecation warning. A call filtered out by the level does not
+// use up key.
+func (l *Logger) LogOnce(level Level, key, format string, v ...any) {
+	if !l.accepts(l.settings(), capLevel(level)) {
+		return
+	}
+	if _, seen := l.onceKeys.LoadOrStore(key, struct{}{}); seen {
+		return
+	}
+	l.log(level, format, v...)
+}
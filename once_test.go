@@ -0,0 +1,34 @@
+package golog
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestLogOnce checks that a key logs once across goroutines and children,
+// and that a filtered call does not use it up.
+func TestLogOnce(t *testing.T) {
+	var buf lockedBuffer
+	l := NewLogger(WithOutput(&buf))
+	l.LogOnce(LevelDebug, "probe", "probe result %d", 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.LogOnce(LevelWarn, "deprecated", "Foo is deprecated, use Bar")
+				l.Named("child").LogOnce(LevelWarn, "deprecated", "other text")
+			}
+		}()
+	}
+	wg.Wait()
+	l.SetLevel(LevelDebug)
+	l.LogOnce(LevelDebug, "probe", "probe result %d", 2)
+
+	want := "[WARN] Foo is deprecated, use Bar \n[DEBUG] probe result 2 \n"
+	if got := buf.buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,93 @@
+package golog
+
+import (
+	"context"
+	"io"
+)
+
+// Option configures a Logger created by NewLogger.
+type Option func(*Logger)
+
+// WithOutput sets the writer console output goes to, instead of os.Stderr.
+func WithOutput(w io.Writer) Option {
+	return func(l *Logger) {
+		l.w = w
+	}
+}
+
+// WithLevel sets the minimum level, instead of LevelInfo.
+func WithLevel(level Level) Option {
+	return func(l *Logger) {
+		l.SetLevel(level)
+	}
+}
+
+// WithShowDetail is the option form of SetShowDetail.
+func WithShowDetail(b bool) Option {
+	return func(l *Logger) {
+		l.SetShowDetail(b)
+	}
+}
+
+// WithShowModulePath is the option form of SetShowModulePath.
+func WithShowModulePath(b bool) Option {
+	return func(l *Logger) {
+		l.SetShowModulePath(b)
+	}
+}
+
+// WithFileFormat is the option form of SetFileFormat.
+func WithFileFormat(f FileFormat) Option {
+	return func(l *Logger) {
+		l.SetFileFormat(f)
+	}
+}
+
+// WithNormalizeWhitespace is the option form of SetNormalizeWhitespace.
+func WithNormalizeWhitespace(b bool) Option {
+	return func(l *Logger) {
+		l.SetNormalizeWhitespace(b)
+	}
+}
+
+// WithFormatter is the option form of SetFormatter.
+func WithFormatter(f Formatter) Option {
+	return func(l *Logger) {
+		l.SetFormatter(f)
+	}
+}
+
+// Options configures NewLoggerWithOptions. Zero fields keep NewLogger's
+// defaults.
+type Options struct {
+	// ChannelBufferSize is how many messages may queue for the file writer
+	// before logging blocks. Zero means 100.
+	ChannelBufferSize int
+	// Level is the minimum level logged. Its zero value, LevelTrace, is
+	// taken as unset and keeps LevelInfo unless LevelSet is true.
+	Level      Level
+	LevelSet   bool
+	Output     io.Writer // Console output; nil means os.Stderr
+	ShowDetail bool
+	// Context, if set, enables the log file with StartWithContext, so the
+	// file writer shuts down when it is done.
+	Context context.Context
+}
+
+// NewLoggerWithOptions returns a logger configured by opts.
+func NewLoggerWithOptions(opts Options) *Logger {
+	l := NewLogger(WithShowDetail(opts.ShowDetail))
+	if opts.Level != LevelTrace || opts.LevelSet {
+		l.SetLevel(opts.Level)
+	}
+	if opts.Output != nil {
+		l.w = opts.Output
+	}
+	if opts.ChannelBufferSize > 0 {
+		l.logChannel = make(chan fileMsg, opts.ChannelBufferSize)
+	}
+	if opts.Context != nil {
+		l.StartWithContext(opts.Context)
+	}
+	return l
+}
@@ -0,0 +1,38 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestWithOutput checks that the option replaces the default writer.
+func TestWithOutput(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.Info("to buffer")
+
+	if buf.String() != "[INFO] to buffer \n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+// TestNewLoggerWithOptions checks that each Options field is applied.
+func TestNewLoggerWithOptions(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLoggerWithOptions(Options{ChannelBufferSize: 1000, Level: LevelWarn, Output: &buf})
+	if cap(l.logChannel) != 1000 {
+		t.Errorf("expected a channel of 1000, got %d", cap(l.logChannel))
+	}
+	l.Info("hidden")
+	l.Warn("shown")
+	if expected := "[WARN] shown \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	if l := NewLoggerWithOptions(Options{}); cap(l.logChannel) != logChannelSize || l.w != stderr || l.GetLevel() != LevelInfo {
+		t.Error("expected NewLogger's defaults for zero fields")
+	}
+	if l := NewLoggerWithOptions(Options{LevelSet: true}); l.GetLevel() != LevelTrace {
+		t.Errorf("expected LevelTrace with LevelSet, got %v", l.GetLevel())
+	}
+}
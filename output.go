@@ -0,0 +1,105 @@
+package golog
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// SetOutput redirects console output of the default logger.
+func SetOutput(w io.Writer) {
+	defaultLogger.SetOutput(w)
+}
+
+// SetOutput replaces the writers console output goes to with w. It is safe
+// to call while other goroutines are logging. A nil w discards console output.
+func (l *Logger) SetOutput(w io.Writer) {
+	if w == nil {
+		w = io.Discard
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.w = w
+}
+
+// AddWriter sends console output to w as well as the writers already set.
+func (l *Logger) AddWriter(w io.Writer) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.w = io.MultiWriter(l.w, w)
+}
+
+// SetWriters replaces the console writers with writers. Each line is written
+// to them in order, stopping at the first error as io.MultiWriter does.
+// Without arguments console output is discarded.
+func (l *Logger) SetWriters(writers ...io.Writer) {
+	if len(writers) == 0 {
+		l.SetOutput(nil)
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.w = io.MultiWriter(writers...)
+}
+
+// writeConsole writes line at level, through the throughput limiter if the
+// logger was wrapped by one.
+func (l *Logger) writeConsole(level Level, line string) error {
+	if l.throughput != nil {
+		return l.throughput.write(l, level, []byte(line))
+	}
+	return l.writeConsoleNow(level, []byte(line))
+}
+
+// writeConsoleNow writes p at level under the mutex, so a line reaches every
+// writer before the next one starts.
+func (l *Logger) writeConsoleNow(level Level, p []byte) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	_, err := l.consoleWriter(level).Write(p)
+	return err
+}
+
+func (l *Logger) writer() io.Writer {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.w
+}
+
+// WriterMiddleware wraps the console writer, e.g. to compress, encrypt or
+// buffer output without the logger knowing.
+type WriterMiddleware func(next io.Writer) io.Writer
+
+// AddWriterMiddleware wraps the current console writer with m. Middleware
+// added later wraps earlier middleware, so it sees each line first. SetOutput
+// and SetWriters replace the writer together with its middleware.
+func (l *Logger) AddWriterMiddleware(m WriterMiddleware) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.w = m(l.w)
+}
+
+// NewCompressionMiddleware gzips console output at level, one of the
+// compress/gzip levels; an invalid level falls back to the default. The
+// stream is flushed after every line, so output can be decompressed up to
+// the last complete line at any time.
+func NewCompressionMiddleware(level int) WriterMiddleware {
+	return func(next io.Writer) io.Writer {
+		zw, err := gzip.NewWriterLevel(next, level)
+		if err != nil {
+			zw = gzip.NewWriter(next)
+		}
+		return gzipWriter{zw}
+	}
+}
+
+type gzipWriter struct {
+	*gzip.Writer
+}
+
+func (w gzipWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, w.Writer.Flush()
+}
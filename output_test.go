@@ -0,0 +1,120 @@
+package golog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestSetOutput checks that the writer can be swapped while other goroutines log.
+func TestSetOutput(t *testing.T) {
+	l := NewLogger(WithOutput(&lockedBuffer{}))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Info("message %d", j)
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		l.SetOutput(&lockedBuffer{})
+	}
+	wg.Wait()
+
+	var buf bytes.Buffer
+	l.SetOutput(&buf)
+	l.Info("after")
+	if buf.Len() == 0 {
+		t.Error("expected output on the new writer")
+	}
+
+	l.SetOutput(nil)
+	l.Info("discarded")
+}
+
+// lockedBuffer is a bytes.Buffer safe for the concurrent writes above.
+type lockedBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+// TestAddWriter checks that added writers receive lines alongside the existing one.
+func TestAddWriter(t *testing.T) {
+	var first, second, third bytes.Buffer
+	l := NewLogger(WithOutput(&first))
+	l.AddWriter(&second)
+	l.Info("both")
+	if first.String() != second.String() || first.Len() == 0 {
+		t.Errorf("expected the same line on both writers, got %q and %q", first.String(), second.String())
+	}
+
+	l.SetWriters(&third)
+	l.Info("only third")
+	if !strings.Contains(third.String(), "only third") || strings.Contains(first.String(), "only third") {
+		t.Error("expected SetWriters to replace the earlier writers")
+	}
+
+	l.SetOutput(&first)
+	l.Info("reset")
+	if strings.Contains(third.String(), "reset") {
+		t.Error("expected SetOutput to reset the writer list")
+	}
+}
+
+// prefixWriter prepends prefix to every write.
+type prefixWriter struct {
+	prefix string
+	next   io.Writer
+}
+
+func (w prefixWriter) Write(p []byte) (int, error) {
+	if _, err := w.next.Write(append([]byte(w.prefix), p...)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// TestAddWriterMiddleware checks that the last middleware added runs first.
+func TestAddWriterMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	for _, prefix := range []string{"first:", "second:"} {
+		prefix := prefix
+		l.AddWriterMiddleware(func(next io.Writer) io.Writer { return prefixWriter{prefix, next} })
+	}
+	l.Info("msg")
+	if want := "first:second:[INFO] msg \n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+// TestCompressionMiddleware checks that every line can be decompressed
+// while the stream is still open.
+func TestCompressionMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.AddWriterMiddleware(NewCompressionMiddleware(gzip.BestCompression))
+	l.Info("one")
+	l.Info("two")
+
+	zr, err := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(zr) // Unexpected EOF: the stream is not closed
+	if want := "[INFO] one \n[INFO] two \n"; string(got) != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
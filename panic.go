@@ -0,0 +1,49 @@
+package golog
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+)
+
+// DefaultMaxGoroutineDumpSize is the goroutine_dump limit used until
+// SetMaxGoroutineDumpSize is called.
+const DefaultMaxGoroutineDumpSize = 64 << 10
+
+var maxGoroutineDumpSize atomic.Int64
+
+func init() {
+	maxGoroutineDumpSize.Store(DefaultMaxGoroutineDumpSize)
+}
+
+// SetMaxGoroutineDumpSize bounds the goroutine_dump field written by
+// LogPanicDetails to n bytes.
+func SetMaxGoroutineDumpSize(n int) {
+	maxGoroutineDumpSize.Store(int64(n))
+}
+
+// LogPanicDetails logs a recovered panic at LevelError with the panic value
+// and its type as panic_value and panic_type, and the stacks of all
+// goroutines as goroutine_dump. Call it from a deferred function:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			golog.LogPanicDetails(logger, r)
+//		}
+//	}()
+func LogPanicDetails(l *Logger, recovered any) {
+	l.WithTypedFields(
+		Field{Key: "panic_value", Value: fmt.Sprint(recovered)},
+		Field{Key: "panic_type", Value: fmt.Sprintf("%T", recovered)},
+		Field{Key: "goroutine_dump", Value: goroutineDump()},
+	).log(LevelError, "panic: %v", recovered)
+}
+
+func goroutineDump() string {
+	size := maxGoroutineDumpSize.Load()
+	if size <= 0 {
+		return ""
+	}
+	buf := make([]byte, size)
+	return string(buf[:runtime.Stack(buf, true)])
+}
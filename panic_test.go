@@ -0,0 +1,42 @@
+package golog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestLogPanicDetails checks the panic fields and that the dump covers other goroutines.
+func TestLogPanicDetails(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	block := make(chan struct{})
+	defer close(block)
+	go func() { <-block }()
+
+	func() {
+		defer func() {
+			LogPanicDetails(l, recover())
+		}()
+		panic("boom")
+	}()
+
+	out := buf.String()
+	for _, want := range []string{"panic: boom", "panic_value=boom", "panic_type=string", "goroutine_dump="} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+	if strings.Count(out, "goroutine ") < 2 {
+		t.Error("expected the dump to include more than one goroutine")
+	}
+}
+
+// TestMaxGoroutineDumpSize checks that the dump is truncated to the limit.
+func TestMaxGoroutineDumpSize(t *testing.T) {
+	SetMaxGoroutineDumpSize(16)
+	defer SetMaxGoroutineDumpSize(DefaultMaxGoroutineDumpSize)
+	if dump := goroutineDump(); len(dump) != 16 {
+		t.Errorf("expected a 16 byte dump, got %d bytes", len(dump))
+	}
+}
@@ -0,0 +1,23 @@
+// Command example is a processor plugin that redacts email addresses.
+//
+//	go build -buildmode=plugin -o redact.so ./plugin/example
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ryqdev/golog"
+)
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// GoLogProcessor is looked up by plugin.LoadProcessorPlugin.
+var GoLogProcessor golog.Processor = func(format string, v ...any) (string, []any) {
+	msg := emailPattern.ReplaceAllString(fmt.Sprintf(format, v...), "[EMAIL]")
+	return strings.ReplaceAll(msg, "%", "%%"), nil
+}
+
+// main lets the package build normally; it is not called when loaded as a plugin.
+func main() {}
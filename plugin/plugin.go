@@ -0,0 +1,49 @@
+//go:build !windows
+
+// Package plugin loads golog processors from Go plugins (.so files) at runtime.
+//
+// A processor plugin is a main package that exports a GoLogProcessor variable
+// or function with the golog.Processor signature; see the example directory.
+// Build it with
+//
+//	go build -buildmode=plugin -o redact.so ./plugin/example
+//
+// Plugins require cgo (CGO_ENABLED=1) and only load into a host built with
+// the same Go toolchain, the same versions of every shared package including
+// golog, and the same flags that affect compilation such as -trimpath, -race
+// or -tags. Windows does not support plugins.
+package plugin
+
+import (
+	"fmt"
+	goplugin "plugin"
+
+	"github.com/ryqdev/golog"
+)
+
+// SymbolName is the symbol looked up in processor plugins.
+const SymbolName = "GoLogProcessor"
+
+// LoadProcessorPlugin opens the plugin at path and returns its
+// GoLogProcessor, ready to pass to Logger.AddProcessor.
+func LoadProcessorPlugin(path string) (golog.Processor, error) {
+	p, err := goplugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup(SymbolName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Exported variables are looked up as pointers, functions as values.
+	switch fn := sym.(type) {
+	case *golog.Processor:
+		return *fn, nil
+	case golog.Processor:
+		return fn, nil
+	case func(string, ...any) (string, []any):
+		return fn, nil
+	}
+	return nil, fmt.Errorf("golog/plugin: %s in %s has type %T, want golog.Processor", SymbolName, path, sym)
+}
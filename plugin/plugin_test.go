@@ -0,0 +1,16 @@
+//go:build !windows
+
+package plugin
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestLoadProcessorPluginMissing checks that a missing plugin file is reported as an error.
+func TestLoadProcessorPluginMissing(t *testing.T) {
+	p, err := LoadProcessorPlugin(filepath.Join(t.TempDir(), "missing.so"))
+	if err == nil || p != nil {
+		t.Errorf("expected error for missing plugin, got %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package golog
+
+import "sync"
+
+// LoggerPool hands out reusable child loggers of a template, for code that
+// needs a short-lived logger per row, session or request.
+type LoggerPool struct {
+	template *Logger
+	pool     sync.Pool
+}
+
+// NewLoggerPool returns a pool of children of template and pre-allocates
+// capacity of them. Pooled loggers share the template's writer, file channel
+// and stats, and start with its level, processors and fields.
+func NewLoggerPool(template *Logger, capacity int) *LoggerPool {
+	p := &LoggerPool{template: template}
+	p.pool.New = func() any { return new(Logger) }
+	for i := 0; i < capacity; i++ {
+		p.pool.Put(new(Logger))
+	}
+	return p
+}
+
+// Get returns a logger reset to a copy of the template. Fields added to it
+// with AddFields are discarded when it is next handed out.
+func (p *LoggerPool) Get() *Logger {
+	l := p.pool.Get().(*Logger)
+	p.template.cloneInto(l)
+	return l
+}
+
+// Put returns l to the pool. l must not be used afterwards.
+func (p *LoggerPool) Put(l *Logger) {
+	p.pool.Put(l)
+}
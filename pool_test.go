@@ -0,0 +1,41 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestLoggerPool checks that pooled loggers share the template output and start without leftover fields.
+func TestLoggerPool(t *testing.T) {
+	var buf bytes.Buffer
+	template := NewLogger(WithOutput(&buf)).WithTypedFields(Field{"svc", "db"})
+	pool := NewLoggerPool(template, 2)
+
+	l := pool.Get()
+	l.AddFields(Field{"row", 1})
+	l.Info("first")
+	pool.Put(l)
+
+	l = pool.Get()
+	l.Info("second")
+	pool.Put(l)
+
+	want := "[INFO] first svc=db row=1 \n" + "[INFO] second svc=db \n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+	if n := template.Stats().ByLevel[LevelInfo]; n != 2 {
+		t.Errorf("expected stats shared with template, got %d", n)
+	}
+}
+
+func BenchmarkLoggerPool(b *testing.B) {
+	var buf bytes.Buffer
+	pool := NewLoggerPool(NewLogger(WithOutput(&buf)), 16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l := pool.Get()
+		l.AddFields(Field{"i", i})
+		pool.Put(l)
+	}
+}
@@ -0,0 +1,10 @@
+package golog
+
+// SetFilePreallocateSize reserves bytes of disk space for each new log file
+// when it is opened, reducing fragmentation on spinning disks. Zero disables
+// preallocation. It takes effect from the next file opened.
+func (l *Logger) SetFilePreallocateSize(bytes int64) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.preallocSize = bytes
+}
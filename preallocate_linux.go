@@ -0,0 +1,23 @@
+//go:build linux
+
+package golog
+
+import (
+	"os"
+	"syscall"
+)
+
+// preallocKeepsSize reports that preallocation leaves the file size alone,
+// so appends keep working.
+const preallocKeepsSize = true
+
+const fallocKeepSize = 0x1 // FALLOC_FL_KEEP_SIZE
+
+// preallocate reserves blocks past offset without changing the file size.
+// It never needs trimming, so it always reports false.
+func preallocate(file *os.File, offset, size int64) bool {
+	if size > offset {
+		syscall.Fallocate(int(file.Fd()), fallocKeepSize, offset, size-offset)
+	}
+	return false
+}
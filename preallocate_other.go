@@ -0,0 +1,17 @@
+//go:build !linux
+
+package golog
+
+import "os"
+
+const preallocKeepsSize = false
+
+// preallocate grows the file to size with Truncate. It reports whether the
+// file was grown, in which case writes must go to the tracked offset and the
+// unused tail is trimmed when the file is closed.
+func preallocate(file *os.File, offset, size int64) bool {
+	if size <= offset {
+		return false
+	}
+	return file.Truncate(size) == nil
+}
@@ -0,0 +1,52 @@
+package golog
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestFilePreallocate checks that reopening a preallocated file appends after the
+// written data rather than after the reserved space.
+func TestFilePreallocate(t *testing.T) {
+	l := NewLogger()
+	l.SetLogDir(t.TempDir())
+	l.SetFilePreallocateSize(1 << 20)
+
+	l.writeToFile("first line\n")
+	l.writeToFile("second line\n")
+	path := l.logFilePath
+	l.currentHour = "stale"
+	l.writeToFile("next file\n")
+	l.closeLogFile()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "first line\nsecond line\nnext file\n" || strings.Contains(string(content), "\x00") {
+		t.Errorf("unexpected content %q", content)
+	}
+}
+
+func benchmarkWriteToFile(b *testing.B, prealloc int64) {
+	l := NewLogger()
+	l.SetLogDir(b.TempDir())
+	l.SetFilePreallocateSize(prealloc)
+	msg := "[INFO] benchmark message with a realistic amount of text in it \n"
+	b.SetBytes(int64(len(msg)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.writeToFile(msg)
+	}
+	b.StopTimer()
+	l.closeLogFile()
+}
+
+func BenchmarkWriteToFile(b *testing.B) {
+	benchmarkWriteToFile(b, 0)
+}
+
+func BenchmarkWriteToFilePreallocated(b *testing.B) {
+	benchmarkWriteToFile(b, 64<<20)
+}
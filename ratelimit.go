@@ -0,0 +1,195 @@
+package golog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// rateLimits is shared by a logger and every child cloned from it, so the
+// budgets cover all of them together.
+type rateLimits struct {
+	mutex   sync.Mutex // Serializes updates
+	current atomic.Pointer[rateLimitSet]
+
+	suppressedMutex sync.Mutex
+	suppressed      map[Level]*suppression // Drops not yet summarized
+}
+
+// rateLimitSet is replaced wholesale, never mutated.
+type rateLimitSet struct {
+	byLevel map[Level]*tokenBucket
+	global  *tokenBucket
+}
+
+// suppression counts the messages dropped at one level since the last
+// message that got through.
+type suppression struct {
+	count int64
+	since time.Time
+}
+
+// SetRateLimit caps messages at level, from l and its children together, at
+// maxPerSecond, allowing that many in a burst. Messages over the limit are
+// dropped and counted in Stats; the next message at level that gets through
+// is preceded by a summary such as "(12 messages suppressed in last 1s)".
+// Zero removes the limit for level.
+func (l *Logger) SetRateLimit(level Level, maxPerSecond int) {
+	l.SetLevelRateLimit(level, float64(maxPerSecond), maxPerSecond)
+}
+
+// SetLevelRateLimit is SetRateLimit with a fractional rate and a separate
+// burst size. A rate of zero or less removes the limit for level.
+func (l *Logger) SetLevelRateLimit(level Level, r float64, burst int) {
+	l.limits.update(func(set *rateLimitSet) {
+		if b := newTokenBucket(r, burst); b != nil {
+			set.byLevel[level] = b
+		} else {
+			delete(set.byLevel, level)
+		}
+	})
+}
+
+// SetGlobalRateLimit caps the messages per second written by l and its
+// children at all levels, allowing bursts of up to burst messages, after
+// any per-level limit. A rate of zero or less removes the limit.
+func (l *Logger) SetGlobalRateLimit(r float64, burst int) {
+	l.limits.update(func(set *rateLimitSet) {
+		set.global = newTokenBucket(r, burst)
+	})
+}
+
+// LevelRateLimitStatus returns the tokens currently available to each
+// per-level limiter.
+func (l *Logger) LevelRateLimitStatus() map[Level]float64 {
+	status := make(map[Level]float64)
+	if set := l.limits.current.Load(); set != nil {
+		for level, b := range set.byLevel {
+			status[level] = b.available()
+		}
+	}
+	return status
+}
+
+func (r *rateLimits) update(fn func(set *rateLimitSet)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	next := rateLimitSet{byLevel: make(map[Level]*tokenBucket)}
+	if set := r.current.Load(); set != nil {
+		for level, b := range set.byLevel {
+			next.byLevel[level] = b
+		}
+		next.global = set.global
+	}
+	fn(&next)
+	r.current.Store(&next)
+}
+
+// allow reports whether a message at level fits the per-level budget and
+// then the global one.
+func (r *rateLimits) allow(level Level) bool {
+	set := r.current.Load()
+	if set == nil {
+		return true
+	}
+	if b := set.byLevel[level]; b != nil && !b.allow() {
+		return false
+	}
+	return set.global == nil || set.global.allow()
+}
+
+func (r *rateLimits) suppress(level Level) {
+	r.suppressedMutex.Lock()
+	defer r.suppressedMutex.Unlock()
+	if r.suppressed == nil {
+		r.suppressed = make(map[Level]*suppression)
+	}
+	s := r.suppressed[level]
+	if s == nil {
+		s = &suppression{since: time.Now()}
+		r.suppressed[level] = s
+	}
+	s.count++
+}
+
+// takeSuppressed returns and clears the drops at level not yet summarized.
+func (r *rateLimits) takeSuppressed(level Level) *suppression {
+	r.suppressedMutex.Lock()
+	defer r.suppressedMutex.Unlock()
+	s := r.suppressed[level]
+	delete(r.suppressed, level)
+	return s
+}
+
+// rateLimited reports whether a message at level is over its budget, counting
+// it as dropped if so.
+func (l *Logger) rateLimited(level Level) bool {
+	if l.limits.allow(level) {
+		return false
+	}
+	l.stats.countDropped(level)
+	l.limits.suppress(level)
+	return true
+}
+
+// reportSuppressed emits the summary of messages at level dropped since the
+// last one that got through. It bypasses processors and the rate limits.
+func (l *Logger) reportSuppressed(ctx context.Context, cfg *LoggerConfig, level Level) {
+	s := l.limits.takeSuppressed(level)
+	if s == nil {
+		return
+	}
+	window := time.Since(s.since).Truncate(time.Second) + time.Second
+	l.dispatch(ctx, record{
+		level:   level,
+		time:    cfg.now(),
+		prefix:  l.prefixText(),
+		content: fmt.Sprintf("(%d messages suppressed in last %v)", s.count, window),
+		cfg:     cfg,
+	})
+}
+
+// tokenBucket holds up to burst tokens, refilled at rate per second; each
+// message takes one.
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(r float64, burst int) *tokenBucket {
+	if r <= 0 {
+		return nil
+	}
+	return &tokenBucket{rate: r, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+func (b *tokenBucket) allow() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (b *tokenBucket) available() float64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	return b.tokens
+}
+
+// refill adds the tokens earned since the last call. The caller must hold
+// mutex.
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
@@ -0,0 +1,79 @@
+package golog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSetLevelRateLimit checks that an exhausted Debug budget leaves Info untouched.
+func TestSetLevelRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf), WithLevel(LevelDebug))
+	l.SetLevelRateLimit(LevelDebug, 0.001, 2)
+	for i := 0; i < 5; i++ {
+		l.Debug("flood")
+	}
+	l.Info("still here")
+
+	if n := strings.Count(buf.String(), "flood"); n != 2 {
+		t.Errorf("expected 2 debug messages, got %d", n)
+	}
+	if !strings.Contains(buf.String(), "still here") {
+		t.Error("expected the info message to pass")
+	}
+	if dropped := l.Stats().Dropped; dropped != 3 {
+		t.Errorf("expected 3 dropped, got %d", dropped)
+	}
+	if tokens := l.LevelRateLimitStatus()[LevelDebug]; tokens >= 1 {
+		t.Errorf("expected the debug limiter to be exhausted, got %v tokens", tokens)
+	}
+}
+
+// TestSetGlobalRateLimit checks that the global limit applies after the per-level one.
+func TestSetGlobalRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetGlobalRateLimit(0.001, 3)
+	l.SetLevelRateLimit(LevelInfo, 0.001, 1)
+	child := l.WithField("child", true)
+	for i := 0; i < 3; i++ {
+		l.Info("info")
+		child.Error("error")
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 3 {
+		t.Errorf("expected 1 info and 2 errors within the shared budget, got %q", buf.String())
+	}
+}
+
+// TestSetRateLimit checks that drops are summarized before the next message
+// that gets through, once.
+func TestSetRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetRateLimit(LevelError, 2)
+	for i := 0; i < 5; i++ {
+		l.Error("storm")
+	}
+	l.SetRateLimit(LevelError, 0)
+	l.Error("recovered")
+	l.Error("calm")
+
+	want := "[ERROR] storm \n[ERROR] storm \n[ERROR] (3 messages suppressed in last 1s) \n[ERROR] recovered \n[ERROR] calm \n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+// TestTokenBucket checks refilling up to the burst size.
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(10, 2)
+	if !b.allow() || !b.allow() || b.allow() {
+		t.Fatal("expected exactly the burst to pass")
+	}
+	b.last = b.last.Add(-time.Second)
+	if got := b.available(); got != 2 {
+		t.Errorf("expected the bucket to refill to its burst, got %v", got)
+	}
+}
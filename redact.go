@@ -0,0 +1,63 @@
+package golog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// minRevealLength is the shortest value that is partially revealed; shorter
+// values are masked entirely since a prefix would give most of them away.
+const minRevealLength = 4
+
+// NewPartialRevealProcessor masks the values of key=value pairs whose key is
+// in sensitiveKeys, keeping the first revealPercent of each value visible, e.g.
+// password=abc******* for revealPercent 0.3. Keys match case-insensitively.
+func NewPartialRevealProcessor(sensitiveKeys []string, revealPercent float64) Processor {
+	if revealPercent < 0 {
+		revealPercent = 0
+	}
+	if revealPercent > 1 {
+		revealPercent = 1
+	}
+	pattern := fieldPattern(sensitiveKeys)
+
+	return func(format string, v ...any) (string, []any) {
+		if pattern == nil {
+			return format, v
+		}
+		msg := pattern.ReplaceAllStringFunc(fmt.Sprintf(format, v...), func(field string) string {
+			key, value, _ := strings.Cut(field, "=")
+			return key + "=" + partialReveal(value, revealPercent)
+		})
+		return escapeFormat(msg), nil
+	}
+}
+
+// fieldPattern matches key=value pairs for any of keys, where the value runs
+// up to the next whitespace, comma, semicolon or ampersand.
+func fieldPattern(keys []string) *regexp.Regexp {
+	if len(keys) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = regexp.QuoteMeta(key)
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)=[^\s,;&]+`)
+}
+
+func partialReveal(value string, revealPercent float64) string {
+	runes := []rune(value)
+	if len(runes) < minRevealLength {
+		return strings.Repeat("*", len(runes))
+	}
+	reveal := int(revealPercent * float64(len(runes)))
+	return string(runes[:reveal]) + strings.Repeat("*", len(runes)-reveal)
+}
+
+// escapeFormat turns an already formatted message back into a format string
+// so processors that work on the final text can hand it down the chain.
+func escapeFormat(msg string) string {
+	return strings.ReplaceAll(msg, "%", "%%")
+}
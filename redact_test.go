@@ -0,0 +1,39 @@
+package golog
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestPartialRevealProcessor checks masking for various value lengths and reveal percentages.
+func TestPartialRevealProcessor(t *testing.T) {
+	cases := []struct {
+		percent float64
+		input   string
+		want    string
+	}{
+		{0.3, "password=abcdefghij", "password=abc*******"},
+		{0.5, "token=abcdef user=bob", "token=abc*** user=bob"},
+		{0, "password=abcdef", "password=******"},
+		{1, "password=abcdef", "password=abcdef"},
+		{0.5, "password=abc", "password=***"},
+		{0.5, "PASSWORD=abcdef, next", "PASSWORD=abc***, next"},
+		{0.5, "mypassword=abcdef", "mypassword=abcdef"},
+	}
+	for _, c := range cases {
+		p := NewPartialRevealProcessor([]string{"password", "token"}, c.percent)
+		format, v := p("%s", c.input)
+		if got := fmt.Sprintf(format, v...); got != c.want {
+			t.Errorf("reveal %.1f of %q: expected %q, got %q", c.percent, c.input, c.want, got)
+		}
+	}
+}
+
+// TestPartialRevealProcessorKeepsPercent checks that literal percent signs survive the processor.
+func TestPartialRevealProcessorKeepsPercent(t *testing.T) {
+	p := NewPartialRevealProcessor([]string{"token"}, 0.5)
+	format, v := p("100%% done token=%s", "abcdef")
+	if got := fmt.Sprintf(format, v...); got != "100% done token=abc***" {
+		t.Errorf("unexpected output %q", got)
+	}
+}
@@ -0,0 +1,84 @@
+package golog
+
+import (
+	"reflect"
+	"sync"
+)
+
+// globalProcessor is a registered processor and the id its unregister
+// function removes.
+type globalProcessor struct {
+	id int
+	p  Processor
+}
+
+var (
+	globalProcessorsMutex sync.RWMutex
+	globalProcessorsNext  int
+	globalProcessors      []globalProcessor
+	globalProcessorFuncs  []Processor
+)
+
+// RegisterGlobalProcessor adds p to the processors that run on every logger,
+// before the logger's own chain. The registry is read on each message rather
+// than copied when a logger is created, so loggers that already exist,
+// including the default logger, pick up p too, and unregistering takes
+// effect everywhere at once. The returned function removes this
+// registration only, even if the same function was registered more than
+// once.
+func RegisterGlobalProcessor(p Processor) (unregister func()) {
+	globalProcessorsMutex.Lock()
+	defer globalProcessorsMutex.Unlock()
+	id := globalProcessorsNext
+	globalProcessorsNext++
+	// Copy on write so loggers can iterate a snapshot without holding the lock.
+	processors := make([]globalProcessor, len(globalProcessors), len(globalProcessors)+1)
+	copy(processors, globalProcessors)
+	setGlobalProcessors(append(processors, globalProcessor{id, p}))
+	return func() {
+		removeGlobalProcessors(func(gp globalProcessor) bool { return gp.id == id })
+	}
+}
+
+// RemoveGlobalProcessor unregisters p. Functions are matched by code pointer,
+// so closures created from the same function literal are indistinguishable
+// and are all removed.
+//
+// Deprecated: call the function returned by RegisterGlobalProcessor, which
+// removes exactly one registration.
+func RemoveGlobalProcessor(p Processor) {
+	target := reflect.ValueOf(p).Pointer()
+	removeGlobalProcessors(func(gp globalProcessor) bool {
+		return reflect.ValueOf(gp.p).Pointer() == target
+	})
+}
+
+// removeGlobalProcessors unregisters every processor match reports true for.
+func removeGlobalProcessors(match func(globalProcessor) bool) {
+	globalProcessorsMutex.Lock()
+	defer globalProcessorsMutex.Unlock()
+	var processors []globalProcessor
+	for _, gp := range globalProcessors {
+		if !match(gp) {
+			processors = append(processors, gp)
+		}
+	}
+	setGlobalProcessors(processors)
+}
+
+// setGlobalProcessors replaces the registry. The caller must hold
+// globalProcessorsMutex.
+func setGlobalProcessors(processors []globalProcessor) {
+	globalProcessors = processors
+	funcs := make([]Processor, len(processors))
+	for i, gp := range processors {
+		funcs[i] = gp.p
+	}
+	globalProcessorFuncs = funcs
+}
+
+func globalProcessorList() []Processor {
+	globalProcessorsMutex.RLock()
+	defer globalProcessorsMutex.RUnlock()
+	return globalProcessorFuncs
+}
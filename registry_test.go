@@ -0,0 +1,63 @@
+package golog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func redactSecret(format string, v ...any) (string, []any) {
+	return strings.ReplaceAll(format, "secret", "[REDACTED]"), v
+}
+
+// TestGlobalProcessor checks that global processors run on every logger, before its own chain.
+func TestGlobalProcessor(t *testing.T) {
+	RegisterGlobalProcessor(redactSecret)
+	defer RemoveGlobalProcessor(redactSecret)
+
+	var buf bytes.Buffer
+	l := NewLogger()
+	l.w = &buf
+	var seen string
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		seen = format
+		return format, v
+	})
+	l.Info("token is secret")
+
+	if seen != "token is [REDACTED]" {
+		t.Errorf("expected logger processor to see the globally processed format, got %q", seen)
+	}
+	if !strings.Contains(buf.String(), "token is [REDACTED]") {
+		t.Errorf("expected redacted message, got %q", buf.String())
+	}
+
+	RemoveGlobalProcessor(redactSecret)
+	buf.Reset()
+	l.Info("token is secret")
+	if !strings.Contains(buf.String(), "token is secret") {
+		t.Errorf("expected processor to be removed, got %q", buf.String())
+	}
+}
+
+// TestUnregisterGlobalProcessor checks that the function returned by
+// RegisterGlobalProcessor removes only its own registration, even among
+// closures from the same literal.
+func TestUnregisterGlobalProcessor(t *testing.T) {
+	replacer := func(old, new string) Processor {
+		return func(format string, v ...any) (string, []any) {
+			return strings.ReplaceAll(format, old, new), v
+		}
+	}
+	unregisterUser := RegisterGlobalProcessor(replacer("alice", "[USER]"))
+	unregisterHost := RegisterGlobalProcessor(replacer("db1", "[HOST]"))
+	defer unregisterHost()
+
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	unregisterUser()
+	l.Info("alice on db1")
+	if got, want := buf.String(), "[INFO] alice on [HOST] \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
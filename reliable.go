@@ -0,0 +1,133 @@
+package golog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// ackByte is what the receiving end of a ReliableWriter sends back for
+	// each message it has accepted.
+	ackByte = 0x06
+
+	// reliableRetries is how many times a ReliableWriter resends a message
+	// that was not acknowledged in time.
+	reliableRetries = 3
+)
+
+// ErrNotAcknowledged is returned by ReliableWriter.Write when a message was
+// never acknowledged.
+var ErrNotAcknowledged = errors.New("golog: message not acknowledged")
+
+// ReliableWriter forwards messages to a log aggregation server that
+// acknowledges each one with a single 0x06 byte, such as a net.Conn.
+type ReliableWriter struct {
+	w          io.Writer
+	ackTimeout time.Duration
+	acks       chan struct{}
+	done       chan struct{} // Closed when the connection stops delivering acks
+	readErr    error         // Why done was closed
+
+	mutex   sync.Mutex // Serializes writes, so acks match messages in order
+	pending atomic.Int64
+}
+
+// NewReliableWriter returns a writer that sends each message to underlying
+// and waits up to ackTimeout for its acknowledgement, which it reads from
+// underlying, so underlying must also be an io.Reader. A message that is not
+// acknowledged is resent up to 3 times before Write reports the failure, so
+// the server may see duplicates. Writes are serialized: each waits for the
+// previous message to be acknowledged or given up on.
+func NewReliableWriter(underlying io.Writer, ackTimeout time.Duration) *ReliableWriter {
+	rw := &ReliableWriter{
+		w:          underlying,
+		ackTimeout: ackTimeout,
+		acks:       make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	r, ok := underlying.(io.Reader)
+	if !ok {
+		rw.readErr = errors.New("golog: reliable writer cannot read acks from a write-only writer")
+		close(rw.done)
+		return rw
+	}
+	go rw.readAcks(r)
+	return rw
+}
+
+func (rw *ReliableWriter) readAcks(r io.Reader) {
+	defer close(rw.done)
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		for _, b := range buf[:n] {
+			if b != ackByte {
+				continue
+			}
+			select {
+			case rw.acks <- struct{}{}:
+			default: // An ack nobody waits for, e.g. after a timeout
+			}
+		}
+		if err != nil {
+			rw.readErr = err
+			return
+		}
+	}
+}
+
+// Write sends p and returns once it is acknowledged. If it never is, the
+// failure goes to the error handler and Write returns ErrNotAcknowledged.
+func (rw *ReliableWriter) Write(p []byte) (int, error) {
+	rw.pending.Add(1)
+	defer rw.pending.Add(-1)
+	rw.mutex.Lock()
+	defer rw.mutex.Unlock()
+
+	// Drop acks that arrived after their message timed out.
+	select {
+	case <-rw.acks:
+	default:
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= reliableRetries; attempt++ {
+		if _, err := rw.w.Write(p); err != nil {
+			lastErr = err
+			continue
+		}
+		timer := time.NewTimer(rw.ackTimeout)
+		select {
+		case <-rw.acks:
+			timer.Stop()
+			return len(p), nil
+		case <-rw.done:
+			timer.Stop()
+			lastErr = rw.readErr
+			attempt = reliableRetries // Acks can no longer arrive
+		case <-timer.C:
+			lastErr = fmt.Errorf("no ack within %v", rw.ackTimeout)
+		}
+	}
+	err := fmt.Errorf("%w: %v", ErrNotAcknowledged, lastErr)
+	reportError(err)
+	return 0, err
+}
+
+// PendingCount returns how many messages have been written but not yet
+// acknowledged or given up on.
+func (rw *ReliableWriter) PendingCount() int64 {
+	return rw.pending.Load()
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (rw *ReliableWriter) Close() error {
+	if c, ok := rw.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package golog
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// ackServer accepts one connection and sends the lines it reads on lines,
+// acknowledging each when ack is set.
+func ackServer(t *testing.T, ack bool) (addr string, lines chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	lines = make(chan string, 16)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+			if ack {
+				conn.Write([]byte{ackByte})
+			}
+		}
+	}()
+	return ln.Addr().String(), lines
+}
+
+// TestReliableWriter checks that acknowledged messages are delivered once
+// and leave nothing pending.
+func TestReliableWriter(t *testing.T) {
+	addr, lines := ackServer(t, true)
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw := NewReliableWriter(conn, time.Second)
+	defer rw.Close()
+
+	l := NewLogger(WithOutput(rw))
+	l.Info("first")
+	l.Info("second")
+
+	for _, want := range []string{"[INFO] first ", "[INFO] second "} {
+		if got := <-lines; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+	if n := rw.PendingCount(); n != 0 {
+		t.Errorf("expected nothing pending, got %d", n)
+	}
+}
+
+// TestReliableWriterRetries checks that an unacknowledged message is resent
+// three times before Write fails.
+func TestReliableWriterRetries(t *testing.T) {
+	addr, lines := ackServer(t, false)
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw := NewReliableWriter(conn, 20*time.Millisecond)
+	defer rw.Close()
+
+	if _, err := rw.Write([]byte("lost\n")); !errors.Is(err, ErrNotAcknowledged) {
+		t.Fatalf("expected ErrNotAcknowledged, got %v", err)
+	}
+	for i := 0; i < 1+reliableRetries; i++ {
+		select {
+		case <-lines:
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d sends, got %d", 1+reliableRetries, i)
+		}
+	}
+	if n := rw.PendingCount(); n != 0 {
+		t.Errorf("expected nothing pending after giving up, got %d", n)
+	}
+}
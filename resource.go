@@ -0,0 +1,111 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// pressure is the state of resource-aware leveling and memory-pressure
+// shedding, shared by a logger and every clone so that pressure quiets all
+// of them and one watcher serves them together.
+type pressure struct {
+	levelFloor         atomic.Int32 // Minimum level enforced under resource pressure
+	goroutineThreshold atomic.Int64 // Goroutine count considered as pressure
+
+	memShedThreshold atomic.Uint64 // Retained bytes at which shedding starts
+	shedLevel        atomic.Int32  // Highest level shed under memory pressure
+	memShedding      atomic.Bool   // Set while messages are being shed
+
+	mutex        sync.Mutex
+	resourceStop chan struct{} // Stops the resource watcher
+	memShedStop  chan struct{} // Stops the memory watcher
+}
+
+const (
+	// DefaultGoroutineThreshold is the goroutine count above which
+	// resource-aware leveling considers the process under pressure.
+	DefaultGoroutineThreshold = 10000
+
+	resourcePollInterval = time.Second
+)
+
+func newPressure() *pressure {
+	p := &pressure{}
+	p.shedLevel.Store(int32(LevelInfo))
+	return p
+}
+
+// SetResourceAwareLeveling starts or stops a background watcher that raises
+// the minimum level to LevelError while the heap is more than 80% in use or
+// the goroutine count exceeds the threshold, and restores it afterwards.
+// The floor applies to l and every child derived from it.
+func (l *Logger) SetResourceAwareLeveling(b bool) {
+	p := l.pressure
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if b == (p.resourceStop != nil) {
+		return
+	}
+	if b {
+		p.resourceStop = make(chan struct{})
+		go l.watchResources(p.resourceStop)
+		return
+	}
+	close(p.resourceStop)
+	p.resourceStop = nil
+	l.setResourcePressure(false)
+}
+
+// SetGoroutineThreshold sets the goroutine count treated as pressure by
+// resource-aware leveling. Zero restores DefaultGoroutineThreshold.
+func (l *Logger) SetGoroutineThreshold(n int) {
+	l.pressure.goroutineThreshold.Store(int64(n))
+}
+
+func (l *Logger) watchResources(stop chan struct{}) {
+	ticker := time.NewTicker(resourcePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.checkResourcePressure()
+		}
+	}
+}
+
+func (l *Logger) checkResourcePressure() {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	threshold := l.pressure.goroutineThreshold.Load()
+	if threshold <= 0 {
+		threshold = DefaultGoroutineThreshold
+	}
+	pressured := m.HeapInuse*5 > m.HeapSys*4 || int64(runtime.NumGoroutine()) > threshold
+	l.setResourcePressure(pressured)
+}
+
+// setResourcePressure raises or clears the level floor. Transitions are
+// reported on os.Stderr rather than through the logger to avoid recursion.
+func (l *Logger) setResourcePressure(pressured bool) {
+	floor := int32(LevelTrace)
+	if pressured {
+		floor = int32(LevelError)
+	}
+	if l.pressure.levelFloor.Swap(floor) == floor {
+		return
+	}
+	if pressured {
+		fmt.Fprintln(os.Stderr, "golog: resource pressure detected, only logging errors")
+	} else {
+		fmt.Fprintln(os.Stderr, "golog: resource pressure subsided, restoring log level")
+	}
+}
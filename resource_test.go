@@ -0,0 +1,62 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestResourcePressureRaisesLevel checks that pressure suppresses Info and that clearing it restores output.
+func TestResourcePressureRaisesLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger()
+	l.w = &buf
+	l.SetGoroutineThreshold(1)
+
+	l.checkResourcePressure()
+	l.Info("suppressed")
+	if buf.Len() != 0 {
+		t.Errorf("expected Info to be suppressed under pressure, got %q", buf.String())
+	}
+	l.Error("kept")
+	if buf.Len() == 0 {
+		t.Error("expected Error to pass under pressure")
+	}
+
+	buf.Reset()
+	l.setResourcePressure(false)
+	l.Info("restored")
+	if buf.Len() == 0 {
+		t.Error("expected Info to pass once pressure subsided")
+	}
+	if l.GetLevel() != LevelInfo {
+		t.Errorf("expected configured level to be untouched, got %v", l.GetLevel())
+	}
+}
+
+// TestSetResourceAwareLevelingToggle checks that the watcher can be started and stopped.
+func TestSetResourceAwareLevelingToggle(t *testing.T) {
+	l := NewLogger()
+	l.SetResourceAwareLeveling(true)
+	l.SetResourceAwareLeveling(true)
+	l.SetResourceAwareLeveling(false)
+	if l.pressure.resourceStop != nil {
+		t.Error("expected watcher to be stopped")
+	}
+}
+
+// TestResourcePressureCoversChildren checks that the level floor raised
+// under pressure also applies to children, including ones made earlier.
+func TestResourcePressureCoversChildren(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	child := l.WithField("k", 1)
+	l.setResourcePressure(true)
+	defer l.setResourcePressure(false)
+
+	child.Info("dropped")
+	l.Named("db").Warn("dropped too")
+	child.Error("kept")
+	if got, want := buf.String(), "[ERROR] kept k=1 \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,105 @@
+package golog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SetRetention deletes rotated log files beyond the newest maxFiles and
+// those last modified more than maxAge ago. Only files in the log directory
+// whose names match the file pattern, including size-rotated ones such as
+// 2006-01-02_15.1.log, are considered, along with their checksum sidecars;
+// the file being written is never deleted. Cleanup runs in the writer
+// whenever it starts a new file, rather than on a timer. Zero means no
+// limit.
+func (l *Logger) SetRetention(maxFiles int, maxAge time.Duration) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.maxFiles = maxFiles
+	l.maxAge = maxAge
+}
+
+// applyRetention deletes the rotated files SetRetention no longer keeps. The
+// caller must hold logFileMutex.
+func (l *Logger) applyRetention() {
+	if l.maxFiles <= 0 && l.maxAge <= 0 {
+		return
+	}
+	dir := l.logDirOrDefault()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		l.reportError(fmt.Errorf("golog: apply retention: %w", err))
+		return
+	}
+	type rotatedFile struct {
+		path    string
+		modTime time.Time
+	}
+	var files []rotatedFile
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if !entry.Type().IsRegular() || path == l.logFilePath || !matchesFilePattern(entry.Name(), l.filePatternOrDefault()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, rotatedFile{path, info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+
+	cutoff := time.Now().Add(-l.maxAge)
+	for i, f := range files {
+		if (l.maxFiles > 0 && i >= l.maxFiles) || (l.maxAge > 0 && f.modTime.Before(cutoff)) {
+			if err := removeLogFile(f.path); err != nil {
+				l.reportError(fmt.Errorf("golog: apply retention: %w", err))
+			}
+		}
+	}
+}
+
+// removeLogFile deletes path and any checksum sidecars written for it.
+func removeLogFile(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, algo := range checksumAlgos {
+		os.Remove(path + algo.ext())
+	}
+	return nil
+}
+
+// matchesFilePattern reports whether name was produced by the time layout
+// pattern, possibly with a size-rotation sequence number before its
+// extension and an optional .gz suffix.
+func matchesFilePattern(name, pattern string) bool {
+	name, _ = trimGzipExt(name)
+	if _, err := time.Parse(pattern, name); err == nil {
+		return true
+	}
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	dot := strings.LastIndexByte(base, '.')
+	if dot < 0 || !isDigits(base[dot+1:]) {
+		return false
+	}
+	_, err := time.Parse(pattern, base[:dot]+ext)
+	return err == nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package golog
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+// retentionDir creates fake rotated files in a new directory, each an hour
+// older than the one before, plus a file that does not match the pattern.
+func retentionDir(t *testing.T) string {
+	dir := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"2020-01-01_03.log", "2020-01-01_02.1.log", "2020-01-01_02.log", "2020-01-01_01.log", "notes.txt"} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte("x\n"), 0644)
+		mtime := now.Add(-time.Duration(i+1) * time.Hour)
+		os.Chtimes(path, mtime, mtime)
+	}
+	os.WriteFile(filepath.Join(dir, "2020-01-01_01.log.sha256"), []byte("sum\n"), 0644)
+	return dir
+}
+
+func remaining(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TestRetentionMaxFiles checks that only the newest rotated files are kept.
+func TestRetentionMaxFiles(t *testing.T) {
+	dir := retentionDir(t)
+	l := NewLogger()
+	l.SetLogDir(dir)
+	l.SetRetention(2, 0)
+	l.writeToFile("[INFO] new file\n")
+	l.closeLogFile()
+
+	current := filepath.Base(l.logFilePath)
+	want := []string{"2020-01-01_02.1.log", "2020-01-01_03.log", current, "notes.txt"}
+	sort.Strings(want)
+	if got := remaining(t, dir); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// TestRetentionMaxAge checks that rotated files past the age limit go.
+func TestRetentionMaxAge(t *testing.T) {
+	dir := retentionDir(t)
+	l := NewLogger()
+	l.SetLogDir(dir)
+	l.SetRetention(0, 150*time.Minute)
+	l.writeToFile("[INFO] new file\n")
+	l.closeLogFile()
+
+	current := filepath.Base(l.logFilePath)
+	want := []string{"2020-01-01_02.1.log", "2020-01-01_03.log", current, "notes.txt"}
+	sort.Strings(want)
+	if got := remaining(t, dir); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// TestMatchesFilePattern checks plain and size-rotated names.
+func TestMatchesFilePattern(t *testing.T) {
+	for name, want := range map[string]bool{
+		"2020-01-01_03.log":    true,
+		"2020-01-01_03.12.log": true,
+		"2020-01-01_03.x.log":  false,
+		"2020-01-01.log":       false,
+		"wal.log":              false,
+	} {
+		if got := matchesFilePattern(name, DefaultFilePattern); got != want {
+			t.Errorf("%s: expected %v, got %v", name, want, got)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package golog
+
+import (
+	"io"
+	"math"
+	"sync"
+)
+
+// recentEntries is the ring buffer behind EnableRingBuffer. It is shared by
+// a logger and every child cloned from it, like subscribers.
+type recentEntries struct {
+	mutex   sync.Mutex
+	entries []Entry // Allocated once by EnableRingBuffer
+	next    int     // Slot the next entry goes to
+	full    bool    // Every slot has been written
+}
+
+func EnableRingBuffer(size int) {
+	defaultLogger.EnableRingBuffer(size)
+}
+
+func RecentEntries(n int) []Entry {
+	return defaultLogger.RecentEntries(n)
+}
+
+func ReplayThroughProcessors(w io.Writer) error {
+	return defaultLogger.ReplayThroughProcessors(w)
+}
+
+// EnableRingBuffer keeps the last size entries written by l or its
+// children in memory, e.g. to attach to a crash report. Entries already
+// kept are discarded. Zero turns the buffer off.
+func (l *Logger) EnableRingBuffer(size int) {
+	r := l.recent
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.entries = nil
+	if size > 0 {
+		r.entries = make([]Entry, size)
+	}
+	r.next, r.full = 0, false
+}
+
+// RecentEntries returns up to n of the most recently written entries,
+// oldest first.
+func (l *Logger) RecentEntries(n int) []Entry {
+	r := l.recent
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	count := r.next
+	if r.full {
+		count = len(r.entries)
+	}
+	if n > count {
+		n = count
+	}
+	if n <= 0 {
+		return nil
+	}
+	out := make([]Entry, n)
+	for i := range out {
+		out[i] = r.entries[(r.next-n+i+len(r.entries))%len(r.entries)]
+	}
+	return out
+}
+
+// ReplayThroughProcessors writes every entry in the ring buffer to w, oldest
+// first, after running its message through l's current processor chain, so
+// a processor added later, e.g. to mask a field, also applies to entries
+// kept from before. The chain is read once up front, so processors added
+// during the replay do not apply to only part of it. Processors that already
+// ran when an entry was logged run again and should be idempotent. Lines are
+// rendered by l's formatter without color; the first write error stops the
+// replay and is returned.
+func (l *Logger) ReplayThroughProcessors(w io.Writer) error {
+	cfg := l.settings()
+	globals := globalProcessorList()
+	processors, lazyProcessors := l.processorList()
+	for _, e := range l.RecentEntries(math.MaxInt) {
+		rec := record{level: e.Level, time: e.Time, file: e.File, line: e.Line, fields: mergeFieldMap(nil, e.Fields), cfg: cfg}
+		rec.content = processMessage(cfg, globals, processors, lazyProcessors, escapeFormat(e.Message))
+		if _, err := io.WriteString(w, l.format(rec, false)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add stores rec in the next slot, overwriting the oldest entry once the
+// buffer is full.
+func (r *recentEntries) add(rec record) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.entries) == 0 {
+		return
+	}
+	r.entries[r.next] = rec.entry()
+	r.next++
+	if r.next == len(r.entries) {
+		r.next, r.full = 0, true
+	}
+}
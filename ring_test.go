@@ -0,0 +1,66 @@
+package golog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestRingBuffer checks overwriting, ordering and that children share the
+// buffer.
+func TestRingBuffer(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+	if got := l.RecentEntries(5); got != nil {
+		t.Errorf("expected no entries while disabled, got %v", got)
+	}
+	l.EnableRingBuffer(3)
+	child := l.Named("worker")
+	for i := 0; i < 4; i++ {
+		l.Info("parent %d", i)
+	}
+	child.Warn("child")
+
+	var got []string
+	for _, e := range l.RecentEntries(10) {
+		got = append(got, fmt.Sprintf("%v %s", e.Level, e.Message))
+	}
+	want := []string{"info parent 2", "info parent 3", "warn child"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if last := l.RecentEntries(1); len(last) != 1 || last[0].Message != "child" {
+		t.Errorf("expected only the newest entry, got %v", last)
+	}
+}
+
+// TestRingBufferAddAllocs checks that storing an entry without fields does
+// not allocate.
+func TestRingBufferAddAllocs(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+	l.EnableRingBuffer(8)
+	rec := l.assembleMsg(l.settings(), LevelInfo, "served")
+	if n := testing.AllocsPerRun(100, func() { l.recent.add(rec) }); n != 0 {
+		t.Errorf("expected no allocations, got %v", n)
+	}
+}
+
+// TestReplayThroughProcessors checks that a processor added after logging
+// applies to the replayed entries.
+func TestReplayThroughProcessors(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+	l.EnableRingBuffer(4)
+	l.Info("token=secret")
+	l.Warn("100% done")
+	l.AddLazyProcessor(func(msg *string) {
+		*msg = strings.ReplaceAll(*msg, "secret", "***")
+	})
+
+	var buf strings.Builder
+	if err := l.ReplayThroughProcessors(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "[INFO] token=*** \n[WARN] 100% done \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,40 @@
+package golog
+
+import "context"
+
+// Route sends messages accepted by Matcher to Target.
+type Route struct {
+	Matcher func(Entry) bool
+	Target  *Logger
+}
+
+// NewRoutingLogger returns a logger that dispatches each message to the
+// Target of the first route whose Matcher accepts it, or to fallback when
+// none does. Messages are assembled once by the routing logger, using its
+// level, processors and detail settings (copied from fallback); the chosen
+// target then applies its own level and writes with its own output.
+//
+// Matchers run in order on every message, so the cost grows linearly with the
+// number of routes. Keep matchers cheap and put the most frequently matching
+// routes first.
+func NewRoutingLogger(routes []Route, fallback *Logger) *Logger {
+	l := fallback.clone()
+	l.routes = append([]Route(nil), routes...)
+	l.fallback = fallback
+	return l
+}
+
+func (l *Logger) route(ctx context.Context, rec record) {
+	target := l.fallback
+	entry := rec.entry()
+	for _, r := range l.routes {
+		if r.Matcher(entry) {
+			target = r.Target
+			break
+		}
+	}
+	if cfg := target.settings(); target.accepts(cfg, rec.level) {
+		rec.cfg = cfg
+		target.emit(ctx, rec)
+	}
+}
@@ -0,0 +1,64 @@
+package golog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRoutingLogger checks that matching messages go to the route target and the rest to the fallback.
+func TestRoutingLogger(t *testing.T) {
+	var main, audit bytes.Buffer
+	fallback := NewLogger()
+	fallback.w = &main
+	auditLogger := NewLogger()
+	auditLogger.w = &audit
+
+	l := NewRoutingLogger([]Route{{
+		Matcher: func(e Entry) bool { return strings.HasPrefix(e.Message, "audit:") },
+		Target:  auditLogger,
+	}}, fallback)
+
+	l.Info("audit: user %s deleted", "bob")
+	l.Info("request served")
+
+	if !strings.Contains(audit.String(), "audit: user bob deleted") || strings.Contains(audit.String(), "request served") {
+		t.Errorf("unexpected audit output %q", audit.String())
+	}
+	if !strings.Contains(main.String(), "request served") || strings.Contains(main.String(), "audit:") {
+		t.Errorf("unexpected main output %q", main.String())
+	}
+}
+
+// TestRoutingLoggerChild checks that children of a routing logger keep its
+// routes and add their own fields.
+func TestRoutingLoggerChild(t *testing.T) {
+	var main, audit bytes.Buffer
+	auditLogger := NewLogger(WithOutput(&audit))
+	l := NewRoutingLogger([]Route{{
+		Matcher: func(e Entry) bool { return strings.HasPrefix(e.Message, "audit:") },
+		Target:  auditLogger,
+	}}, NewLogger(WithOutput(&main)))
+
+	l.WithField("k", 1).Info("audit: user %s deleted", "bob")
+	if got, want := audit.String(), "[INFO] audit: user bob deleted k=1 \n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if main.Len() != 0 {
+		t.Errorf("expected nothing on the fallback, got %q", main.String())
+	}
+}
+
+// TestRoutingLoggerTargetLevel checks that the target's own level still applies.
+func TestRoutingLoggerTargetLevel(t *testing.T) {
+	var out bytes.Buffer
+	target := NewLogger()
+	target.w = &out
+	target.SetLevel(LevelError)
+
+	l := NewRoutingLogger([]Route{{Matcher: func(Entry) bool { return true }, Target: target}}, NewLogger())
+	l.Info("filtered by target")
+	if out.Len() != 0 {
+		t.Errorf("expected target level to filter Info, got %q", out.String())
+	}
+}
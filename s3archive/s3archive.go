@@ -0,0 +1,99 @@
+// Package s3archive uploads rotated golog files to S3-compatible object storage.
+package s3archive
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/ryqdev/golog"
+)
+
+type archiver struct {
+	logger       *golog.Logger
+	client       *s3.Client
+	bucket       string
+	prefix       string
+	kmsKeyID     string
+	storageClass string
+	deleteLocal  bool
+}
+
+// Option configures an archiver created by NewS3Archiver.
+type Option func(*archiver)
+
+// WithSSEKMS encrypts uploaded objects server-side with the given KMS key.
+func WithSSEKMS(keyID string) Option {
+	return func(a *archiver) {
+		a.kmsKeyID = keyID
+	}
+}
+
+// WithStorageClass sets the storage class of uploaded objects, e.g. "STANDARD_IA".
+func WithStorageClass(class string) Option {
+	return func(a *archiver) {
+		a.storageClass = class
+	}
+}
+
+// WithDeleteLocal removes the local file once it has been uploaded successfully.
+func WithDeleteLocal(b bool) Option {
+	return func(a *archiver) {
+		a.deleteLocal = b
+	}
+}
+
+// NewS3Archiver returns a rotation callback that uploads each rotated file to
+// bucket as prefix/<filename>. Register it with l.SetRotationCallback.
+// Upload failures are reported through l.
+func NewS3Archiver(l *golog.Logger, bucket, prefix string, client *s3.Client, opts ...Option) func(rotatedPath string) {
+	a := &archiver{
+		logger: l,
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a.archive
+}
+
+func (a *archiver) archive(rotatedPath string) {
+	if err := a.upload(rotatedPath); err != nil {
+		a.logger.Error("s3archive: upload %s: %v", rotatedPath, err)
+		return
+	}
+	if a.deleteLocal {
+		if err := os.Remove(rotatedPath); err != nil {
+			a.logger.Error("s3archive: remove %s: %v", rotatedPath, err)
+		}
+	}
+}
+
+func (a *archiver) upload(rotatedPath string) error {
+	file, err := os.Open(rotatedPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	key := path.Join(a.prefix, filepath.Base(rotatedPath))
+	input := &s3.PutObjectInput{
+		Bucket: &a.bucket,
+		Key:    &key,
+		Body:   file,
+	}
+	if a.kmsKeyID != "" {
+		input.ServerSideEncryption = types.ServerSideEncryptionAwsKms
+		input.SSEKMSKeyId = &a.kmsKeyID
+	}
+	if a.storageClass != "" {
+		input.StorageClass = types.StorageClass(a.storageClass)
+	}
+	_, err = a.client.PutObject(context.Background(), input)
+	return err
+}
@@ -0,0 +1,60 @@
+package s3archive
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/ryqdev/golog"
+)
+
+// TestS3Archiver checks that a rotated file is uploaded under the prefix with the configured options.
+func TestS3Archiver(t *testing.T) {
+	var gotPath, gotClass, gotSSE, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotPath = r.URL.Path
+		gotClass = r.Header.Get("X-Amz-Storage-Class")
+		gotSSE = r.Header.Get("X-Amz-Server-Side-Encryption")
+		gotBody = string(body)
+	}))
+	defer srv.Close()
+
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Credentials:  aws.AnonymousCredentials{},
+	})
+
+	rotated := filepath.Join(t.TempDir(), "2024-09-17_12.log")
+	if err := os.WriteFile(rotated, []byte("[INFO] archived line\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := NewS3Archiver(golog.NewLogger(), "logs", "app/host1", client,
+		WithStorageClass("STANDARD_IA"), WithSSEKMS("key-id"), WithDeleteLocal(true))
+	archive(rotated)
+
+	if gotPath != "/logs/app/host1/2024-09-17_12.log" {
+		t.Errorf("unexpected object path %q", gotPath)
+	}
+	if gotClass != "STANDARD_IA" {
+		t.Errorf("expected storage class STANDARD_IA, got %q", gotClass)
+	}
+	if gotSSE != "aws:kms" {
+		t.Errorf("expected aws:kms encryption, got %q", gotSSE)
+	}
+	if !strings.Contains(gotBody, "archived line") {
+		t.Errorf("expected file content in body, got %q", gotBody)
+	}
+	if _, err := os.Stat(rotated); !os.IsNotExist(err) {
+		t.Error("expected local file to be deleted after upload")
+	}
+}
@@ -0,0 +1,54 @@
+package golog
+
+import (
+	"fmt"
+)
+
+// SetPanicSafeProcessors makes a panicking processor, global, eager or lazy,
+// be skipped instead of crashing the logging goroutine. The panic value is
+// written to os.Stderr, not through the logger, and the message continues
+// through the remaining processors as if the panicking one were absent.
+func (l *Logger) SetPanicSafeProcessors(b bool) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.PanicSafeProcessors = b })
+}
+
+func runProcessor(cfg *LoggerConfig, process Processor, format string, v []any) (string, []any) {
+	if !cfg.PanicSafeProcessors {
+		return process(format, v...)
+	}
+	outFormat, outV, recovered := recoverProcessor(process, format, v)
+	if recovered != nil {
+		reportProcessorPanic(cfg, recovered)
+		return format, v
+	}
+	return outFormat, outV
+}
+
+func recoverProcessor(process Processor, format string, v []any) (outFormat string, outV []any, recovered any) {
+	defer func() { recovered = recover() }()
+	outFormat, outV = process(format, v...)
+	return
+}
+
+func runLazyProcessor(cfg *LoggerConfig, process LazyProcessor, msg *string) {
+	if !cfg.PanicSafeProcessors {
+		process(msg)
+		return
+	}
+	edited := *msg
+	if recovered := recoverLazyProcessor(process, &edited); recovered != nil {
+		reportProcessorPanic(cfg, recovered)
+		return
+	}
+	*msg = edited
+}
+
+func recoverLazyProcessor(process LazyProcessor, msg *string) (recovered any) {
+	defer func() { recovered = recover() }()
+	process(msg)
+	return
+}
+
+func reportProcessorPanic(cfg *LoggerConfig, recovered any) {
+	handleError(cfg, fmt.Errorf("golog: processor panicked, skipping it: %v", recovered))
+}
@@ -0,0 +1,30 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestPanicSafeProcessors checks that panicking processors are skipped and
+// the remaining ones still run.
+func TestPanicSafeProcessors(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetPanicSafeProcessors(true)
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		panic("broken processor")
+	})
+	l.AddProcessor(func(format string, v ...any) (string, []any) {
+		return "[ok] " + format, v
+	})
+	l.AddLazyProcessor(func(msg *string) {
+		*msg = "half edited"
+		panic("broken lazy processor")
+	})
+
+	l.Info("first")
+	l.Info("second %d", 2)
+	if want := "[INFO] [ok] first \n[INFO] [ok] second 2 \n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
@@ -0,0 +1,32 @@
+package golog
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// stderr is the default output of every logger. Sharing one wrapper means
+// loggers created separately still serialize their writes to the terminal.
+var stderr = NewSafeWriter(os.Stderr)
+
+type safeWriter struct {
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+// NewSafeWriter returns a writer that serializes calls to w.Write, so lines
+// from concurrent goroutines or loggers sharing w never interleave. Wrapping
+// an already safe writer returns it unchanged.
+func NewSafeWriter(w io.Writer) io.Writer {
+	if sw, ok := w.(*safeWriter); ok {
+		return sw
+	}
+	return &safeWriter{w: w}
+}
+
+func (s *safeWriter) Write(p []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.w.Write(p)
+}
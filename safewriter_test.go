@@ -0,0 +1,43 @@
+package golog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestSafeWriterConcurrent checks that concurrent whole-line writes arrive
+// intact. Run with -race to check the wrapped buffer is not accessed concurrently.
+func TestSafeWriterConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(NewSafeWriter(&buf)))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l.Info("goroutine %03d", i)
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("expected 100 lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "[INFO] goroutine ") {
+			t.Errorf("unexpected interleaved line %q", line)
+		}
+	}
+}
+
+// TestNewSafeWriterIdempotent checks that wrapping twice does not add a second lock.
+func TestNewSafeWriterIdempotent(t *testing.T) {
+	w := NewSafeWriter(&bytes.Buffer{})
+	if NewSafeWriter(w) != w {
+		t.Error("expected wrapping a safe writer to return it unchanged")
+	}
+}
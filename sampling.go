@@ -0,0 +1,90 @@
+package golog
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+)
+
+// sampler is shared by a logger and every child cloned from it, so the
+// counts cover all of them together.
+type sampler struct {
+	every       atomic.Uint64 // Set by SetSampling, off below 2
+	levelCounts [numLevels]atomic.Uint64
+
+	messageEvery atomic.Uint64 // Set by SetMessageSampling, off below 2
+	messages     sync.Map      // Hash of the format string -> *atomic.Uint64, see SetHashFunc
+
+	rate atomic.Uint64 // math.Float64bits of the SetSamplingRate rate
+}
+
+func newSampler() *sampler {
+	s := &sampler{}
+	s.rate.Store(math.Float64bits(1))
+	return s
+}
+
+// SetSampling writes only every n-th message at each level, starting with
+// the first, and silently drops the rest. The count is kept per level, for
+// l and its children together, regardless of the message. n of 0 or 1
+// turns sampling off.
+func (l *Logger) SetSampling(n uint64) {
+	l.sampling.every.Store(n)
+}
+
+// SetMessageSampling is SetSampling counted per format string rather than
+// per level, so a message logged in a hot loop is thinned out while rare
+// ones are always written. One counter is kept for every format string
+// seen, keyed by its hash; see SetHashFunc.
+func (l *Logger) SetMessageSampling(n uint64) {
+	l.sampling.messageEvery.Store(n)
+}
+
+// SetSamplingRate writes each message with probability rate, independently
+// of SetSampling and SetMessageSampling, and silently drops the rest. Like
+// them it covers l and its children together, and it can be changed while
+// other goroutines are logging, e.g. from the endpoint of HTTPHandler. A rate
+// of 1 or more, the default, turns it off; 0 or less drops every message.
+func (l *Logger) SetSamplingRate(rate float64) {
+	if math.IsNaN(rate) || rate > 1 {
+		rate = 1
+	} else if rate < 0 {
+		rate = 0
+	}
+	l.sampling.rate.Store(math.Float64bits(rate))
+}
+
+// SamplingRate returns the rate set with SetSamplingRate.
+func (l *Logger) SamplingRate() float64 {
+	return math.Float64frombits(l.sampling.rate.Load())
+}
+
+// sampled reports whether the message at level with format passes both
+// kinds of sampling under cfg.
+func (l *Logger) sampled(cfg *LoggerConfig, level Level, format string) bool {
+	s := l.sampling
+	if rate := math.Float64frombits(s.rate.Load()); rate < 1 && rand.Float64() >= rate {
+		return false
+	}
+	if n := s.every.Load(); n > 1 && level >= 0 && int(level) < numLevels {
+		if (s.levelCounts[level].Add(1)-1)%n != 0 {
+			return false
+		}
+	}
+	if n := s.messageEvery.Load(); n > 1 {
+		hash := cfg.HashFunc
+		if hash == nil {
+			hash = fnv64a
+		}
+		key := hash(format)
+		counter, ok := s.messages.Load(key)
+		if !ok {
+			counter, _ = s.messages.LoadOrStore(key, new(atomic.Uint64))
+		}
+		if (counter.(*atomic.Uint64).Add(1)-1)%n != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package golog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestSetSampling checks that one in n messages per level is written.
+func TestSetSampling(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetSampling(10)
+	child := l.Named("worker")
+	for i := 0; i < 50; i++ {
+		l.Info("request %d", i)
+		child.Warn("slow %d", i)
+	}
+	if n := strings.Count(buf.String(), "[INFO]"); n != 5 {
+		t.Errorf("expected 5 of 50 info messages, got %d", n)
+	}
+	if n := strings.Count(buf.String(), "[WARN]"); n != 5 {
+		t.Errorf("expected 5 of 50 warn messages from the child, got %d", n)
+	}
+
+	buf.Reset()
+	l.SetSampling(1)
+	for i := 0; i < 10; i++ {
+		l.Info("request %d", i)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 10 {
+		t.Errorf("expected sampling to be off, got %d lines", n)
+	}
+}
+
+// TestSetMessageSampling checks that repeated templates are thinned out
+// while rare ones are always written.
+func TestSetMessageSampling(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetMessageSampling(4)
+	for i := 0; i < 100; i++ {
+		l.Info("cache miss for key %d", i)
+		if i == 50 {
+			l.Info("reloaded config")
+		}
+	}
+	if n := strings.Count(buf.String(), "cache miss"); n != 25 {
+		t.Errorf("expected 25 of 100 cache misses, got %d", n)
+	}
+	if !strings.Contains(buf.String(), "reloaded config") {
+		t.Errorf("expected the rare message to be written, got %q", buf.String())
+	}
+}
+
+// TestSetSamplingRate checks that roughly the given fraction of messages is
+// written and that the rate can be changed while logging.
+func TestSetSamplingRate(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	if rate := l.SamplingRate(); rate != 1 {
+		t.Errorf("expected a default rate of 1, got %v", rate)
+	}
+	l.SetSamplingRate(0.2)
+	child := l.Named("worker")
+	for i := 0; i < 1000; i++ {
+		child.Info("request %d", i)
+	}
+	if n := strings.Count(buf.String(), "\n"); n < 100 || n > 300 {
+		t.Errorf("expected about 200 of 1000 messages, got %d", n)
+	}
+
+	buf.Reset()
+	l.SetSamplingRate(0)
+	l.Info("dropped")
+	l.SetSamplingRate(1)
+	l.Info("kept")
+	if expected := "[INFO] kept \n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/ryqdev/golog/sentrylog
+
+go 1.24.0
+
+require (
+	github.com/getsentry/sentry-go v0.44.1
+	github.com/ryqdev/golog v0.1.0
+)
+
+require (
+	go.opentelemetry.io/otel v1.40.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/term v0.20.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
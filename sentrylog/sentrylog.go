@@ -0,0 +1,54 @@
+// Package sentrylog forwards golog error entries to Sentry.
+package sentrylog
+
+import (
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ryqdev/golog"
+)
+
+// fatalFlushTimeout bounds how long a Fatal entry waits for Sentry before
+// the program exits.
+const fatalFlushTimeout = 2 * time.Second
+
+type sentryHook struct {
+	hub *sentry.Hub
+}
+
+// NewSentryHook returns a hook for Logger.AddHook that captures Error, Panic
+// and Fatal entries on hub, with the entry's fields as extra data:
+//
+//	l.AddHook(sentrylog.NewSentryHook(sentry.CurrentHub()))
+//
+// Capturing only queues the event, so the hook does not wait for Sentry,
+// except on Fatal entries, which flush before the program exits.
+func NewSentryHook(hub *sentry.Hub) golog.Hook {
+	return sentryHook{hub: hub}
+}
+
+func (h sentryHook) Levels() []golog.Level {
+	return golog.LevelsFrom(golog.LevelError)
+}
+
+func (h sentryHook) Fire(entry golog.Entry) error {
+	event := sentry.NewEvent()
+	event.Level = sentryLevel(entry.Level)
+	event.Message = entry.Message
+	event.Timestamp = entry.Time
+	for k, v := range entry.Fields {
+		event.Extra[k] = v
+	}
+	h.hub.CaptureEvent(event)
+	if entry.Level == golog.LevelFatal {
+		h.hub.Flush(fatalFlushTimeout)
+	}
+	return nil
+}
+
+func sentryLevel(level golog.Level) sentry.Level {
+	if level >= golog.LevelPanic {
+		return sentry.LevelFatal
+	}
+	return sentry.LevelError
+}
@@ -0,0 +1,64 @@
+package sentrylog
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ryqdev/golog"
+)
+
+// captureTransport keeps events in memory instead of sending them.
+type captureTransport struct {
+	mutex  sync.Mutex
+	events []*sentry.Event
+	sent   chan struct{}
+}
+
+func (t *captureTransport) Configure(sentry.ClientOptions) {}
+func (t *captureTransport) Flush(time.Duration) bool       { return true }
+func (t *captureTransport) FlushWithContext(context.Context) bool {
+	return true
+}
+func (t *captureTransport) Close() {}
+
+func (t *captureTransport) SendEvent(event *sentry.Event) {
+	t.mutex.Lock()
+	t.events = append(t.events, event)
+	t.mutex.Unlock()
+	t.sent <- struct{}{}
+}
+
+// TestSentryHook checks that errors reach Sentry with their fields and infos do not.
+func TestSentryHook(t *testing.T) {
+	transport := &captureTransport{sent: make(chan struct{}, 1)}
+	if err := sentry.Init(sentry.ClientOptions{Transport: transport}); err != nil {
+		t.Fatal(err)
+	}
+
+	l := golog.NewLogger(golog.WithOutput(&nopWriter{}))
+	l.AddHook(NewSentryHook(sentry.CurrentHub()))
+	l.Info("ignored")
+	l.WithField("order", 42).Error("payment failed")
+
+	select {
+	case <-transport.sent:
+	case <-time.After(time.Second):
+		t.Fatal("no event captured")
+	}
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	if len(transport.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(transport.events))
+	}
+	event := transport.events[0]
+	if event.Message != "payment failed" || event.Level != sentry.LevelError || event.Extra["order"] != 42 {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
+
+type nopWriter struct{}
+
+func (nopWriter) Write(p []byte) (int, error) { return len(p), nil }
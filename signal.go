@@ -0,0 +1,45 @@
+package golog
+
+import (
+	"os"
+	"os/signal"
+)
+
+// ReopenOnSignal closes and reopens the current log file whenever one of
+// sigs arrives, typically syscall.SIGHUP from a logrotate postrotate script
+// (see cmd/gologrotate). After logrotate has moved the file away, this
+// creates a new one at the original path. The rotation callback is not
+// called, since the file was rotated externally. The returned function
+// stops listening.
+func (l *Logger) ReopenOnSignal(sigs ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sigs...)
+	go func() {
+		for {
+			select {
+			case <-ch:
+				l.reopenLogFile()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
+
+// reopenLogFile replaces the current log file with a freshly opened one at
+// the same path. It does nothing if no file is open yet.
+func (l *Logger) reopenLogFile() {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	if l.logFile == nil {
+		return
+	}
+	l.closeLogFile()
+	l.logFile = nil
+	l.openLogFile(l.currentHour)
+}
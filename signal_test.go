@@ -0,0 +1,48 @@
+//go:build !windows
+
+package golog
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestReopenOnSignal checks that SIGHUP recreates a log file moved away by logrotate.
+func TestReopenOnSignal(t *testing.T) {
+	l := NewLogger()
+	stop := l.ReopenOnSignal(syscall.SIGHUP)
+	defer stop()
+
+	l.writeToFile("[INFO] before rotation\n")
+	l.logFileMutex.Lock()
+	path := l.logFilePath
+	l.logFileMutex.Unlock()
+	rotated := path + ".1"
+	defer os.Remove(path)
+	defer os.Remove(rotated)
+	if err := os.Rename(path, rotated); err != nil {
+		t.Fatal(err)
+	}
+
+	syscall.Kill(os.Getpid(), syscall.SIGHUP)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the log file to be recreated after SIGHUP")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	l.writeToFile("[INFO] after rotation\n")
+	l.logFileMutex.Lock()
+	l.closeLogFile()
+	l.logFileMutex.Unlock()
+	if content, _ := os.ReadFile(path); string(content) != "[INFO] after rotation\n" {
+		t.Errorf("unexpected new file content %q", content)
+	}
+}
@@ -0,0 +1,52 @@
+package golog
+
+import "io"
+
+type sink struct {
+	w        io.Writer
+	minLevel Level
+}
+
+// AddSink adds a writer that receives every message at or above minLevel,
+// independently of the logger's own level. A message is assembled when either
+// the logger's level or any sink's level lets it through.
+func (l *Logger) AddSink(w io.Writer, minLevel Level) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	// Copy on write so writeSinks can iterate a snapshot without the lock.
+	sinks := make([]sink, len(l.sinks), len(l.sinks)+1)
+	copy(sinks, l.sinks)
+	l.sinks = append(sinks, sink{w: w, minLevel: minLevel})
+}
+
+func (l *Logger) sinkList() []sink {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.sinks
+}
+
+// accepts reports whether a message at level reaches the logger's own output
+// or at least one sink.
+func (l *Logger) accepts(cfg *LoggerConfig, level Level) bool {
+	if l.enabled(cfg, level) {
+		return true
+	}
+	for _, s := range l.sinkList() {
+		if l.sinkEnabled(s, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Logger) sinkEnabled(s sink, level Level) bool {
+	return level >= s.minLevel && level >= Level(l.pressure.levelFloor.Load())
+}
+
+func (l *Logger) writeSinks(level Level, line string) {
+	for _, s := range l.sinkList() {
+		if l.sinkEnabled(s, level) {
+			s.w.Write([]byte(line))
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package golog
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestAddSink checks that each sink only receives messages at or above its own level.
+func TestAddSink(t *testing.T) {
+	var infoSink, errorSink bytes.Buffer
+	l := NewLogger()
+	l.w = io.Discard
+	l.SetLevel(LevelError)
+	l.AddSink(&infoSink, LevelInfo)
+	l.AddSink(&errorSink, LevelError)
+
+	l.Debug("debug message")
+	l.Info("info message")
+	l.Error("error message")
+
+	if strings.Contains(infoSink.String(), "debug message") {
+		t.Errorf("info sink received debug message: %q", infoSink.String())
+	}
+	if !strings.Contains(infoSink.String(), "info message") || !strings.Contains(infoSink.String(), "error message") {
+		t.Errorf("info sink missing messages: %q", infoSink.String())
+	}
+	if strings.Contains(errorSink.String(), "info message") {
+		t.Errorf("error sink received info message: %q", errorSink.String())
+	}
+	if !strings.Contains(errorSink.String(), "error message") {
+		t.Errorf("error sink missing error message: %q", errorSink.String())
+	}
+}
@@ -0,0 +1,53 @@
+//go:build go1.21
+
+package golog
+
+import "log/slog"
+
+// InfoAttrs logs msg at Info with attrs as fields. It eases migrating
+// log/slog call sites to golog.
+func (l *Logger) InfoAttrs(msg string, attrs ...slog.Attr) {
+	l.WithTypedFields(AttrsToFields(attrs)...).log(LevelInfo, escapeFormat(msg))
+}
+
+func (l *Logger) DebugAttrs(msg string, attrs ...slog.Attr) {
+	l.WithTypedFields(AttrsToFields(attrs)...).log(LevelDebug, escapeFormat(msg))
+}
+
+func (l *Logger) WarnAttrs(msg string, attrs ...slog.Attr) {
+	l.WithTypedFields(AttrsToFields(attrs)...).log(LevelWarn, escapeFormat(msg))
+}
+
+func (l *Logger) ErrorAttrs(msg string, attrs ...slog.Attr) {
+	l.WithTypedFields(AttrsToFields(attrs)...).log(LevelError, escapeFormat(msg))
+}
+
+// AttrsToFields converts slog attributes to fields. Groups are flattened
+// into dotted keys ("req.method") and empty attributes are dropped.
+func AttrsToFields(attrs []slog.Attr) []Field {
+	var fields []Field
+	for _, attr := range attrs {
+		fields = appendAttr(fields, "", attr)
+	}
+	return fields
+}
+
+func appendAttr(fields []Field, prefix string, attr slog.Attr) []Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+	key := attr.Key
+	if prefix != "" && key != "" {
+		key = prefix + "." + key
+	} else if prefix != "" {
+		key = prefix
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, member := range attr.Value.Group() {
+			fields = appendAttr(fields, key, member)
+		}
+		return fields
+	}
+	return append(fields, Field{Key: key, Value: attr.Value.Any()})
+}
@@ -0,0 +1,30 @@
+//go:build go1.21
+
+package golog
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestInfoAttrs checks that slog attributes, including groups, are logged as fields.
+func TestInfoAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger()
+	l.w = &buf
+	l.InfoAttrs("request done", slog.Int("status", 200), slog.Group("req", slog.String("method", "GET")))
+
+	if !strings.Contains(buf.String(), "request done status=200 req.method=GET") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+// TestAttrsToFields checks that empty attributes are dropped and values resolved.
+func TestAttrsToFields(t *testing.T) {
+	fields := AttrsToFields([]slog.Attr{{}, slog.Bool("ok", true)})
+	if len(fields) != 1 || fields[0] != (Field{"ok", true}) {
+		t.Errorf("unexpected fields %v", fields)
+	}
+}
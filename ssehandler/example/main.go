@@ -0,0 +1,39 @@
+// Command example serves a page that tails a logger through ssehandler.
+//
+//	go run ./ssehandler/example
+//
+// then open http://localhost:8080.
+package main
+
+import (
+	_ "embed"
+	"net/http"
+	"time"
+
+	"github.com/ryqdev/golog"
+	"github.com/ryqdev/golog/ssehandler"
+)
+
+//go:embed index.html
+var page []byte
+
+func main() {
+	l := golog.NewLogger()
+	go func() {
+		for i := 0; ; i++ {
+			if i%5 == 4 {
+				l.Error("tick %d failed", i)
+			} else {
+				l.Info("tick %d", i)
+			}
+			time.Sleep(time.Second)
+		}
+	}()
+
+	http.Handle("/logs", ssehandler.NewSSEHandler(l))
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+	l.Error("%v", http.ListenAndServe("localhost:8080", nil))
+}
@@ -0,0 +1,150 @@
+// Package ssehandler streams golog entries to browsers as Server-Sent Events.
+package ssehandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+// bufferSize is how many entries may wait for a slow client before newer
+// ones are dropped for it. Logging never blocks on a client.
+const bufferSize = 256
+
+// Option configures a handler created by NewSSEHandler.
+type Option func(*handler)
+
+// WithAllowedOrigins lets browsers on origins connect across origins. "*"
+// allows any origin. Without it only same-origin pages can connect.
+func WithAllowedOrigins(origins ...string) Option {
+	return func(h *handler) {
+		h.origins = append(h.origins, origins...)
+	}
+}
+
+type handler struct {
+	l       *golog.Logger
+	origins []string
+}
+
+// event is the JSON payload of each message.
+type event struct {
+	Level  string         `json:"level"`
+	Time   string         `json:"ts"`
+	File   string         `json:"file,omitempty"`
+	Msg    string         `json:"msg"`
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+// NewSSEHandler returns a handler that streams every entry l and its
+// children write, one "data: <json>" message each. Entries at LevelError and
+// above are sent as "error" events, so a page can listen for them apart:
+//
+//	source.onmessage = e => show(JSON.parse(e.data))
+//	source.addEventListener("error", e => alert(JSON.parse(e.data).msg))
+func NewSSEHandler(l *golog.Logger, opts ...Option) http.Handler {
+	h := &handler{l: l}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if allowed := h.allowOrigin(origin); allowed != "" {
+			w.Header().Set("Access-Control-Allow-Origin", allowed)
+		}
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	entries := make(chan golog.Entry, bufferSize)
+	unsubscribe := h.l.Subscribe(func(e golog.Entry) {
+		select {
+		case entries <- e:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-entries:
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (h *handler) allowOrigin(origin string) string {
+	for _, o := range h.origins {
+		if o == "*" || o == origin {
+			return o
+		}
+	}
+	return ""
+}
+
+func writeEvent(w http.ResponseWriter, e golog.Entry) error {
+	data, err := json.Marshal(event{
+		Level:  levelName(e.Level),
+		Time:   e.Time.Format(time.RFC3339Nano),
+		File:   location(e),
+		Msg:    e.Message,
+		Fields: e.Fields,
+	})
+	if err != nil {
+		return err
+	}
+	if e.Level >= golog.LevelError {
+		if _, err := fmt.Fprint(w, "event: error\n"); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
+
+func location(e golog.Entry) string {
+	if e.File == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", e.File, e.Line)
+}
+
+func levelName(level golog.Level) string {
+	switch level {
+	case golog.LevelTrace:
+		return "TRACE"
+	case golog.LevelDebug:
+		return "DEBUG"
+	case golog.LevelInfo:
+		return "INFO"
+	case golog.LevelWarn:
+		return "WARN"
+	case golog.LevelError:
+		return "ERROR"
+	case golog.LevelPanic:
+		return "PANIC"
+	case golog.LevelFatal:
+		return "FATAL"
+	}
+	return fmt.Sprintf("LEVEL(%d)", level)
+}
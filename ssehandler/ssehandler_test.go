@@ -0,0 +1,85 @@
+package ssehandler
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+// TestSSEHandler checks the event stream format and the error event type.
+func TestSSEHandler(t *testing.T) {
+	l := golog.NewLogger(golog.WithOutput(io.Discard))
+	server := httptest.NewServer(NewSSEHandler(l, WithAllowedOrigins("https://example.com")))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	req.Header.Set("Origin", "https://example.com")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Errorf("expected the origin to be allowed, got %q", got)
+	}
+
+	l.WithField("user", "bob").Info("hello")
+	l.Error("broken")
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	var got []string
+	for len(got) < 5 {
+		select {
+		case line := <-lines:
+			got = append(got, line)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after %q", got)
+		}
+	}
+
+	var first event
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(got[0], "data: ")), &first); err != nil {
+		t.Fatalf("expected a JSON data line, got %q", got[0])
+	}
+	if first.Level != "INFO" || first.Msg != "hello" || first.Fields["user"] != "bob" || got[1] != "" {
+		t.Errorf("unexpected first event %q", got[:2])
+	}
+	if got[2] != "event: error" || !strings.Contains(got[3], `"msg":"broken"`) {
+		t.Errorf("expected an error event, got %q", got[2:])
+	}
+}
+
+// TestSSEHandlerDisallowedOrigin checks that unknown origins get no CORS header.
+func TestSSEHandlerDisallowedOrigin(t *testing.T) {
+	l := golog.NewLogger(golog.WithOutput(io.Discard))
+	server := httptest.NewServer(NewSSEHandler(l))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	req.Header.Set("Origin", "https://evil.example")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no CORS header, got %q", got)
+	}
+}
@@ -0,0 +1,98 @@
+package golog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const maxLevels = 16
+
+// errDropped is returned by internal writers that deliberately discard a
+// message, so the logger can count it as dropped.
+var errDropped = errors.New("golog: message dropped")
+
+// reportError writes an internal error that has no caller to return to to
+// stderr. It is the default error handler, and the only one for code
+// outside a logger.
+func reportError(err error) {
+	fmt.Fprintln(os.Stderr, "golog:", strings.TrimPrefix(err.Error(), "golog: "))
+}
+
+// Stats is a snapshot of how many messages a logger and its children emitted.
+type Stats struct {
+	ByLevel map[Level]int64
+	Dropped int64 // Messages discarded after passing the level check
+}
+
+// StatsHook is called after each counter update, with the level of the
+// message and whether it was dropped rather than written.
+type StatsHook func(level Level, dropped bool)
+
+// loggerStats is shared by a logger and every child cloned from it.
+type loggerStats struct {
+	counts     [maxLevels]int64
+	dropped    int64
+	ctxDropped int64 // File sends abandoned by the Ctx variants
+
+	mutex sync.Mutex
+	hooks []StatsHook
+	tags  map[string]int64 // Written messages per tag
+
+	alerter atomic.Pointer[volumeAlerter] // Set by SetVolumeAlertBuckets
+}
+
+func newLoggerStats() *loggerStats {
+	return &loggerStats{}
+}
+
+// Stats returns the message counts for l and the loggers derived from it.
+func (l *Logger) Stats() Stats {
+	s := Stats{
+		ByLevel: make(map[Level]int64),
+		Dropped: atomic.LoadInt64(&l.stats.dropped),
+	}
+	for level := range l.stats.counts {
+		if n := atomic.LoadInt64(&l.stats.counts[level]); n > 0 {
+			s.ByLevel[Level(level)] = n
+		}
+	}
+	return s
+}
+
+// AddStatsHook registers h to observe counter updates, e.g. to mirror them
+// into a metrics system.
+func (l *Logger) AddStatsHook(h StatsHook) {
+	l.stats.mutex.Lock()
+	defer l.stats.mutex.Unlock()
+	hooks := make([]StatsHook, len(l.stats.hooks), len(l.stats.hooks)+1)
+	copy(hooks, l.stats.hooks)
+	l.stats.hooks = append(hooks, h)
+}
+
+func (s *loggerStats) count(level Level) {
+	if level >= 0 && level < maxLevels {
+		atomic.AddInt64(&s.counts[level], 1)
+	}
+	if a := s.alerter.Load(); a != nil {
+		a.observe(level)
+	}
+	s.notify(level, false)
+}
+
+func (s *loggerStats) countDropped(level Level) {
+	atomic.AddInt64(&s.dropped, 1)
+	s.notify(level, true)
+}
+
+func (s *loggerStats) notify(level Level, dropped bool) {
+	s.mutex.Lock()
+	hooks := s.hooks
+	s.mutex.Unlock()
+	for _, h := range hooks {
+		h(level, dropped)
+	}
+}
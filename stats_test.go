@@ -0,0 +1,30 @@
+package golog
+
+import (
+	"io"
+	"testing"
+)
+
+// TestStats checks that counts are shared with child loggers and reported to hooks.
+func TestStats(t *testing.T) {
+	l := NewLogger()
+	l.w = io.Discard
+	var hooked int
+	l.AddStatsHook(func(level Level, dropped bool) {
+		if level == LevelError && !dropped {
+			hooked++
+		}
+	})
+
+	l.Info("one")
+	l.Debug("filtered")
+	l.WithTypedFields(Field{"k", "v"}).Error("two")
+
+	stats := l.Stats()
+	if stats.ByLevel[LevelInfo] != 1 || stats.ByLevel[LevelError] != 1 || stats.ByLevel[LevelDebug] != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if hooked != 1 {
+		t.Errorf("expected hook to see 1 error, got %d", hooked)
+	}
+}
@@ -0,0 +1,52 @@
+package golog
+
+import "sync"
+
+// subscribers is shared by a logger and every child cloned from it, so a
+// subscription on a parent also sees messages from loggers derived later.
+type subscribers struct {
+	mutex sync.Mutex
+	next  int
+	fns   map[int]func(Entry)
+}
+
+// Subscribe calls fn with every entry written by l or its children. fn runs
+// synchronously on the logging goroutine, so it should be quick. The returned
+// function removes the subscription.
+func (l *Logger) Subscribe(fn func(Entry)) (unsubscribe func()) {
+	s := l.subscribers
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	id := s.next
+	s.next++
+	fns := make(map[int]func(Entry), len(s.fns)+1)
+	for k, v := range s.fns {
+		fns[k] = v
+	}
+	fns[id] = fn
+	s.fns = fns
+	return func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		fns := make(map[int]func(Entry), len(s.fns))
+		for k, v := range s.fns {
+			if k != id {
+				fns[k] = v
+			}
+		}
+		s.fns = fns
+	}
+}
+
+func (s *subscribers) publish(rec record) {
+	s.mutex.Lock()
+	fns := s.fns
+	s.mutex.Unlock()
+	if len(fns) == 0 {
+		return
+	}
+	entry := rec.entry()
+	for _, fn := range fns {
+		fn(entry)
+	}
+}
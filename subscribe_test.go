@@ -0,0 +1,25 @@
+package golog
+
+import (
+	"io"
+	"testing"
+)
+
+// TestSubscribe checks that subscribers see child messages and stop after unsubscribing.
+func TestSubscribe(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+	var got []Entry
+	unsubscribe := l.Subscribe(func(e Entry) { got = append(got, e) })
+
+	l.WithCorrelationID("abc").Info("hello %d", 1)
+	l.Debug("below level")
+	unsubscribe()
+	l.Info("after unsubscribe")
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(got))
+	}
+	if got[0].Message != "hello 1" || got[0].Fields[CorrelationIDKey] != "abc" {
+		t.Errorf("unexpected entry %+v", got[0])
+	}
+}
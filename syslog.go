@@ -0,0 +1,72 @@
+//go:build !windows
+
+package golog
+
+import (
+	"io"
+	"log/syslog"
+	"strings"
+)
+
+type syslogWriter struct {
+	w *syslog.Writer
+}
+
+// NewSyslogWriter connects to the syslog daemon at addr over network, as
+// syslog.Dial does (an empty network connects to the local daemon), and
+// returns a writer for SetOutput or AddWriter. Each line is sent with the
+// priority of its level tag: Trace and Debug as LOG_DEBUG, Info as LOG_INFO,
+// Warn as LOG_WARNING, Error as LOG_ERR and Panic and Fatal as LOG_CRIT.
+// Lines without a recognized tag, such as JSON, are sent as LOG_NOTICE.
+func NewSyslogWriter(network, addr, tag string) (io.Writer, error) {
+	w, err := syslog.Dial(network, addr, syslog.LOG_NOTICE|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, err
+	}
+	return syslogWriter{w}, nil
+}
+
+func (s syslogWriter) Write(p []byte) (int, error) {
+	level, msg := splitLevelTag(string(p))
+	var err error
+	switch level {
+	case "TRACE", "DEBUG":
+		err = s.w.Debug(msg)
+	case "INFO":
+		err = s.w.Info(msg)
+	case "WARN":
+		err = s.w.Warning(msg)
+	case "ERROR":
+		err = s.w.Err(msg)
+	case "PANIC", "FATAL":
+		err = s.w.Crit(msg)
+	default:
+		err = s.w.Notice(msg)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// splitLevelTag returns the level name from a leading, possibly colored,
+// "[LEVEL]" tag and the rest of line after it. Without a tag, level is
+// empty and msg is line.
+func splitLevelTag(line string) (level, msg string) {
+	rest := line
+	if strings.HasPrefix(rest, "\033[") {
+		if end := strings.IndexByte(rest, 'm'); end >= 0 {
+			rest = rest[end+1:]
+		}
+	}
+	if !strings.HasPrefix(rest, "[") {
+		return "", line
+	}
+	end := strings.IndexByte(rest, ']')
+	if end < 0 {
+		return "", line
+	}
+	level, rest = rest[1:end], rest[end+1:]
+	rest = strings.TrimPrefix(rest, Reset)
+	return level, strings.TrimSpace(rest)
+}
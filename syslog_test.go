@@ -0,0 +1,61 @@
+//go:build !windows
+
+package golog
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSyslogWriter checks the priority each level is sent with.
+func TestSyslogWriter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	w, err := NewSyslogWriter("udp", conn.LocalAddr().String(), "golog-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := NewLogger(WithOutput(w), WithLevel(LevelTrace))
+	l.SetColorEnabled(true)
+
+	// LOG_USER is facility 1, so the priority is 8 plus the severity.
+	for _, tc := range []struct {
+		log  func(string, ...any)
+		want string
+	}{
+		{l.Debug, "<15>"},
+		{l.Info, "<14>"},
+		{l.Warn, "<12>"},
+		{l.Error, "<11>"},
+	} {
+		tc.log("disk check")
+		buf := make([]byte, 1024)
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packet := string(buf[:n])
+		if !strings.HasPrefix(packet, tc.want) || !strings.Contains(packet, " golog-test[") || !strings.HasSuffix(packet, "]: disk check\n") {
+			t.Errorf("expected priority %s and bare message, got %q", tc.want, packet)
+		}
+	}
+}
+
+// TestSplitLevelTag checks plain, colored and untagged lines.
+func TestSplitLevelTag(t *testing.T) {
+	for line, want := range map[string][2]string{
+		"[INFO] hello \n":         {"INFO", "hello"},
+		InfoLevel + " colored \n": {"INFO", "colored"},
+		`{"level":"INFO"}` + "\n": {"", `{"level":"INFO"}` + "\n"},
+	} {
+		if level, msg := splitLevelTag(line); level != want[0] || msg != want[1] {
+			t.Errorf("%q: expected %q, got %q and %q", line, want, level, msg)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package golog
+
+import "strings"
+
+// TagsKey is the field key WithTags stores tags under.
+const TagsKey = "tags"
+
+// tagList renders as "http,slow" in text and as a JSON array in JSON.
+type tagList []string
+
+func (t tagList) String() string {
+	return strings.Join(t, ",")
+}
+
+type tagFilter struct {
+	tag    string
+	action FilterAction
+}
+
+// WithTags returns a child logger that tags every message with tags, in
+// addition to any tags l already has. Tags are written as the tags field.
+func (l *Logger) WithTags(tags ...string) *Logger {
+	var merged tagList
+	for _, f := range l.fieldList() {
+		if existing, ok := f.Value.(tagList); ok && f.Key == TagsKey {
+			merged = append(merged, existing...)
+		}
+	}
+	for _, tag := range tags {
+		if !contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	return l.WithField(TagsKey, merged)
+}
+
+// AddTagFilter drops messages carrying tag, or with Allow, keeps only
+// messages carrying tag or the tag of another Allow filter. Drop filters win
+// over Allow filters. Child loggers created afterwards inherit the filters.
+func (l *Logger) AddTagFilter(tag string, action FilterAction) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	filters := make([]tagFilter, len(l.tagFilters), len(l.tagFilters)+1)
+	copy(filters, l.tagFilters)
+	l.tagFilters = append(filters, tagFilter{tag: tag, action: action})
+}
+
+func (l *Logger) tagFilterList() []tagFilter {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.tagFilters
+}
+
+// TagStatistics returns how many written messages carried each tag, across
+// l and the loggers derived from it.
+func (l *Logger) TagStatistics() map[string]int64 {
+	s := l.stats
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	counts := make(map[string]int64, len(s.tags))
+	for tag, n := range s.tags {
+		counts[tag] = n
+	}
+	return counts
+}
+
+func (l *Logger) tagsAllowed(rec record) bool {
+	filters := l.tagFilterList()
+	if len(filters) == 0 {
+		return true
+	}
+	tags := recordTags(rec)
+	allowed, restricted := false, false
+	for _, f := range filters {
+		has := contains(tags, f.tag)
+		if f.action == Drop && has {
+			return false
+		}
+		if f.action == Allow {
+			restricted = true
+			allowed = allowed || has
+		}
+	}
+	return allowed || !restricted
+}
+
+func recordTags(rec record) tagList {
+	for _, f := range rec.fields {
+		if tags, ok := f.Value.(tagList); ok && f.Key == TagsKey {
+			return tags
+		}
+	}
+	return nil
+}
+
+func (s *loggerStats) countTags(rec record) {
+	tags := recordTags(rec)
+	if len(tags) == 0 {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.tags == nil {
+		s.tags = make(map[string]int64)
+	}
+	for _, tag := range tags {
+		s.tags[tag]++
+	}
+}
@@ -0,0 +1,50 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// TestWithTags checks that tags render comma-separated in text and as an array in JSON.
+func TestWithTags(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf)).WithTags("http").WithTags("slow", "http")
+	l.Info("GET /")
+	expected := "[INFO] GET / tags=http,slow \n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	l.SetFormatter(JSONFormatter{})
+	l.Info("GET /")
+	var got struct{ Tags []string }
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil || len(got.Tags) != 2 || got.Tags[1] != "slow" {
+		t.Errorf("expected a tags array, got %q", buf.String())
+	}
+}
+
+// TestAddTagFilter checks Allow and Drop filters and the per-tag counts.
+func TestAddTagFilter(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.AddTagFilter("http", Allow)
+	l.AddTagFilter("db", Allow)
+	l.AddTagFilter("noisy", Drop)
+
+	l.Info("untagged")
+	l.WithTags("http").Info("request")
+	l.WithTags("db").Info("query")
+	l.WithTags("http", "noisy").Info("health check")
+
+	expected := fmt.Sprintf("%s request tags=http \n%s query tags=db \n", "[INFO]", "[INFO]")
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+	stats := l.TagStatistics()
+	if stats["http"] != 1 || stats["db"] != 1 || stats["noisy"] != 0 {
+		t.Errorf("unexpected tag statistics %v", stats)
+	}
+}
@@ -0,0 +1,45 @@
+package golog
+
+// TB is the part of testing.TB that SetTestMode needs, so that the package
+// does not import testing into non-test binaries. *testing.T and *testing.B
+// implement it.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Cleanup(func())
+}
+
+func SetTestMode(tb TB) {
+	defaultLogger.SetTestMode(tb)
+}
+
+// SetTestMode makes every Error (or more severe) message from l and loggers
+// derived from it afterwards fail tb, so error paths exercised by a test
+// cannot go unnoticed. Test mode is cleared when tb finishes.
+func (l *Logger) SetTestMode(tb TB) {
+	l.setTestTB(tb)
+	tb.Cleanup(func() { l.setTestTB(nil) })
+}
+
+func (l *Logger) setTestTB(tb TB) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.testTB = tb
+}
+
+func (l *Logger) testTBFunc() TB {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.testTB
+}
+
+// failTest reports rec to the test set by SetTestMode, if any.
+func (l *Logger) failTest(rec record) {
+	if rec.level < LevelError {
+		return
+	}
+	if tb := l.testTBFunc(); tb != nil {
+		tb.Helper()
+		tb.Errorf("unexpected error log: %s", rec.content)
+	}
+}
@@ -0,0 +1,45 @@
+package golog
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Cleanup(func()) {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// TestSetTestMode checks that Error messages fail the test and lower levels do not.
+func TestSetTestMode(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	l := NewLogger(WithOutput(io.Discard))
+	l.SetTestMode(tb)
+
+	l.Info("fine")
+	l.WithTypedFields(Field{"id", 1}).Error("boom %d", 42)
+
+	if len(tb.errors) != 1 || tb.errors[0] != "unexpected error log: boom 42" {
+		t.Errorf("expected one reported error, got %q", tb.errors)
+	}
+}
+
+// TestSetTestModeCleanup checks that test mode ends with the test that set it.
+func TestSetTestModeCleanup(t *testing.T) {
+	l := NewLogger(WithOutput(io.Discard))
+	t.Run("inner", func(t *testing.T) {
+		l.SetTestMode(t)
+	})
+	if l.testTBFunc() != nil {
+		t.Error("expected test mode to be cleared after the subtest finished")
+	}
+}
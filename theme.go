@@ -0,0 +1,233 @@
+package golog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ColorTheme holds the ANSI escape sequence used for each level tag.
+// An empty entry leaves that level uncolored.
+type ColorTheme struct {
+	Trace string
+	Debug string
+	Info  string
+	Warn  string
+	Error string
+	Panic string
+	Fatal string
+}
+
+var (
+	// DefaultTheme matches the TraceLevel, DebugLevel, InfoLevel, WarnLevel,
+	// ErrorLevel, PanicLevel and FatalLevel constants.
+	DefaultTheme = ColorTheme{
+		Trace: Cyan,
+		Debug: Blue,
+		Info:  Green,
+		Warn:  Yellow,
+		Error: Red,
+		Panic: Purple,
+		Fatal: Purple,
+	}
+
+	ThemeSolarizedDark = ColorTheme{
+		Trace: color256(37),  // cyan
+		Debug: color256(244), // base0
+		Info:  color256(64),  // green
+		Warn:  color256(136), // yellow
+		Error: color256(160), // red
+		Panic: color256(125), // magenta
+		Fatal: color256(125), // magenta
+	}
+	ThemeSolarizedLight = ColorTheme{
+		Trace: color256(37),  // cyan
+		Debug: color256(241), // base00
+		Info:  color256(64),  // green
+		Warn:  color256(136), // yellow
+		Error: color256(160), // red
+		Panic: color256(125), // magenta
+		Fatal: color256(125), // magenta
+	}
+	ThemeMonokai = ColorTheme{
+		Trace: color256(81),  // blue
+		Debug: color256(242), // comment
+		Info:  color256(148), // green
+		Warn:  color256(208), // orange
+		Error: color256(197), // pink
+		Panic: color256(141), // purple
+		Fatal: color256(141), // purple
+	}
+	ThemeNord = ColorTheme{
+		Trace: color256(109), // nord7
+		Debug: color256(110), // nord8
+		Info:  color256(108), // nord14
+		Warn:  color256(222), // nord13
+		Error: color256(131), // nord11
+		Panic: color256(139), // nord15
+		Fatal: color256(139), // nord15
+	}
+	ThemeGruvboxDark = ColorTheme{
+		Trace: color256(108), // aqua
+		Debug: color256(245), // gray
+		Info:  color256(142), // green
+		Warn:  color256(214), // yellow
+		Error: color256(167), // red
+		Panic: color256(175), // purple
+		Fatal: color256(175), // purple
+	}
+)
+
+func color256(code int) string {
+	return fmt.Sprintf("\033[38;5;%dm", code)
+}
+
+// SetColorTheme sets the colors used for level tags on the default logger.
+func SetColorTheme(theme ColorTheme) {
+	defaultLogger.SetColorTheme(theme)
+}
+
+func (l *Logger) SetColorTheme(theme ColorTheme) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.Theme = theme })
+}
+
+// LoadThemeFromFile reads a theme from a JSON file whose values are SGR
+// parameters, e.g. {"debug":"38;5;244","info":"38;5;71","warn":"38;5;136","error":"38;5;160"}. The optional "trace", "panic" and
+// "fatal" keys color those levels.
+func LoadThemeFromFile(path string) (ColorTheme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ColorTheme{}, err
+	}
+	var raw struct {
+		Trace string `json:"trace"`
+		Debug string `json:"debug"`
+		Info  string `json:"info"`
+		Warn  string `json:"warn"`
+		Error string `json:"error"`
+		Panic string `json:"panic"`
+		Fatal string `json:"fatal"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return ColorTheme{}, fmt.Errorf("golog: parse theme %s: %w", path, err)
+	}
+
+	var theme ColorTheme
+	for _, field := range []struct {
+		name string
+		code string
+		dst  *string
+	}{
+		{"trace", raw.Trace, &theme.Trace},
+		{"debug", raw.Debug, &theme.Debug},
+		{"info", raw.Info, &theme.Info},
+		{"warn", raw.Warn, &theme.Warn},
+		{"error", raw.Error, &theme.Error},
+		{"panic", raw.Panic, &theme.Panic},
+		{"fatal", raw.Fatal, &theme.Fatal},
+	} {
+		if field.code == "" {
+			continue
+		}
+		if !validSGR(field.code) {
+			return ColorTheme{}, fmt.Errorf("golog: theme %s: invalid %s color %q", path, field.name, field.code)
+		}
+		*field.dst = "\033[" + field.code + "m"
+	}
+	return theme, nil
+}
+
+// validSGR reports whether code is a list of numeric SGR parameters such as "38;5;244".
+func validSGR(code string) bool {
+	if code == "" || code[0] == ';' || code[len(code)-1] == ';' {
+		return false
+	}
+	for _, c := range code {
+		if (c < '0' || c > '9') && c != ';' {
+			return false
+		}
+	}
+	return true
+}
+
+// colorAllowedByEnv honors the NO_COLOR convention (https://no-color.org) and
+// dumb terminals, which cannot render escape sequences.
+func colorAllowedByEnv() bool {
+	return os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+}
+
+// levelColor returns the color cfg's theme gives level.
+func levelColor(cfg *LoggerConfig, level Level) string {
+	theme := cfg.Theme
+	if color := themeColor(&theme, level); color != nil {
+		return *color
+	}
+	return ""
+}
+
+// themeColor returns the entry of theme for level, or nil if it has none.
+func themeColor(theme *ColorTheme, level Level) *string {
+	switch level {
+	case LevelTrace:
+		return &theme.Trace
+	case LevelDebug:
+		return &theme.Debug
+	case LevelInfo:
+		return &theme.Info
+	case LevelWarn:
+		return &theme.Warn
+	case LevelError:
+		return &theme.Error
+	case LevelPanic:
+		return &theme.Panic
+	case LevelFatal:
+		return &theme.Fatal
+	}
+	return nil
+}
+
+// SetLevelColor sets the color of level's tag on the default logger.
+func SetLevelColor(level Level, ansiCode string) error {
+	return defaultLogger.SetLevelColor(level, ansiCode)
+}
+
+// SetLevelColor sets the color of level's tag to ansiCode, a complete SGR
+// escape sequence such as Cyan or "\033[1;32m" for bold green, starting
+// with the next message. An empty code leaves the tag uncolored.
+func (l *Logger) SetLevelColor(level Level, ansiCode string) error {
+	if ansiCode != "" && !validEscape(ansiCode) {
+		return fmt.Errorf("golog: invalid ANSI color %q", ansiCode)
+	}
+	var err error
+	l.Transact(func(cfg *LoggerConfig) {
+		color := themeColor(&cfg.Theme, level)
+		if color == nil {
+			err = fmt.Errorf("golog: no color for level %v", level)
+			return
+		}
+		*color = ansiCode
+	})
+	return err
+}
+
+// ResetLevelColors restores the default theme on the default logger.
+func ResetLevelColors() {
+	defaultLogger.ResetLevelColors()
+}
+
+// ResetLevelColors restores DefaultTheme, undoing SetLevelColor and
+// SetColorTheme.
+func (l *Logger) ResetLevelColors() {
+	l.SetColorTheme(DefaultTheme)
+}
+
+// validEscape reports whether code is a single SGR escape sequence.
+func validEscape(code string) bool {
+	inner, ok := strings.CutPrefix(code, "\033[")
+	if !ok {
+		return false
+	}
+	inner, ok = strings.CutSuffix(inner, "m")
+	return ok && validSGR(inner)
+}
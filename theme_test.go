@@ -0,0 +1,139 @@
+package golog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestThemePresets checks that every preset defines well-formed ANSI sequences.
+func TestThemePresets(t *testing.T) {
+	presets := map[string]ColorTheme{
+		"SolarizedDark":  ThemeSolarizedDark,
+		"SolarizedLight": ThemeSolarizedLight,
+		"Monokai":        ThemeMonokai,
+		"Nord":           ThemeNord,
+		"GruvboxDark":    ThemeGruvboxDark,
+	}
+	for name, theme := range presets {
+		for _, seq := range []string{theme.Debug, theme.Info, theme.Warn, theme.Error} {
+			if !strings.HasPrefix(seq, "\033[") || !strings.HasSuffix(seq, "m") {
+				t.Errorf("%s: malformed sequence %q", name, seq)
+				continue
+			}
+			if !validSGR(strings.TrimSuffix(strings.TrimPrefix(seq, "\033["), "m")) {
+				t.Errorf("%s: invalid SGR parameters in %q", name, seq)
+			}
+		}
+	}
+}
+
+// TestSetColorTheme checks that the theme is applied to level tags.
+func TestSetColorTheme(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger()
+	l.w = &buf
+	l.SetColorEnabled(true)
+	l.SetColorTheme(ThemeNord)
+	l.Info("themed")
+
+	if !strings.HasPrefix(buf.String(), ThemeNord.Info+"[INFO]"+Reset) {
+		t.Errorf("expected Nord info color, got %q", buf.String())
+	}
+}
+
+// TestLoadThemeFromFile checks parsing of the JSON theme format and rejection of bad codes.
+func TestLoadThemeFromFile(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.json")
+	os.WriteFile(good, []byte(`{"debug":"38;5;244","info":"38;5;71","warn":"38;5;136","error":"38;5;160"}`), 0644)
+
+	theme, err := LoadThemeFromFile(good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Info != "\033[38;5;71m" || theme.Error != "\033[38;5;160m" {
+		t.Errorf("unexpected theme %q", theme)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	os.WriteFile(bad, []byte(`{"info":"\u001b[32m"}`), 0644)
+	if _, err := LoadThemeFromFile(bad); err == nil {
+		t.Error("expected error for invalid color code")
+	}
+}
+
+// TestNoColorEnv checks that NO_COLOR and TERM=dumb disable level tag colors.
+func TestNoColorEnv(t *testing.T) {
+	for _, env := range [][2]string{{"NO_COLOR", "1"}, {"TERM", "dumb"}} {
+		t.Run(env[0], func(t *testing.T) {
+			t.Setenv(env[0], env[1])
+			if NewLogger().Config().ColorEnabled {
+				t.Error("expected colors to start disabled")
+			}
+		})
+	}
+}
+
+// TestSetColorEnabled checks that non-terminal writers are uncolored by
+// default and that an explicit setting overrides detection.
+func TestSetColorEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetShowDetail(true)
+	l.Info("plain")
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("expected no ANSI codes for a buffer, got %q", buf.String())
+	}
+
+	buf.Reset()
+	l.SetColorEnabled(true)
+	l.Info("colored")
+	if !strings.HasPrefix(buf.String(), InfoLevel+" ") {
+		t.Errorf("expected a colored tag, got %q", buf.String())
+	}
+	if strings.Count(buf.String(), "\033[") != 2 {
+		t.Errorf("expected only the tag itself to be colored, got %q", buf.String())
+	}
+
+	buf.Reset()
+	l.SetColorEnabled(false)
+	l.Info("plain")
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("expected no ANSI codes once disabled, got %q", buf.String())
+	}
+}
+
+// TestSetLevelColor checks remapping a tag color, rejecting bad codes and
+// restoring the defaults.
+func TestSetLevelColor(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetColorEnabled(true)
+	bold := "\033[1;36m"
+	if err := l.SetLevelColor(LevelInfo, bold); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("remapped")
+	if want := bold + "[INFO]" + Reset + " remapped \n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	for _, code := range []string{"36", "\033[36", "\033[3x6m", "\033]36m"} {
+		if err := l.SetLevelColor(LevelInfo, code); err == nil {
+			t.Errorf("expected %q to be rejected", code)
+		}
+	}
+	if err := l.SetLevelColor(Level(42), Cyan); err == nil {
+		t.Error("expected an error for a level without a color")
+	}
+
+	buf.Reset()
+	l.ResetLevelColors()
+	l.Info("default")
+	if want := InfoLevel + " default \n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
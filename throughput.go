@@ -0,0 +1,174 @@
+package golog
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	throughputBuckets   = 10
+	throughputBucketDur = time.Second / throughputBuckets
+
+	// DefaultMaxOverflowBytes is the overflow buffer size used when
+	// ThroughputLimiter.MaxOverflowBytes is left at zero.
+	DefaultMaxOverflowBytes = 1 << 20
+)
+
+// ThroughputLimiter caps the bytes per second written by the loggers it wraps.
+// Messages over the budget are held in an overflow buffer and written out as
+// capacity frees up. Only console output is limited; the file channel is not.
+type ThroughputLimiter struct {
+	// MaxOverflowBytes bounds the overflow buffer. When it is full, new
+	// messages block until there is room, or are dropped if DropOnOverflow
+	// is set. Zero means DefaultMaxOverflowBytes.
+	MaxOverflowBytes int64
+
+	maxBytesPerSecond int64
+	dropOnOverflow    bool
+
+	mutex         sync.Mutex
+	room          *sync.Cond // signalled when overflow is drained
+	buckets       [throughputBuckets]int64
+	bucketStart   time.Time
+	bucketIdx     int
+	overflow      []pendingWrite
+	overflowBytes int64
+	draining      bool
+}
+
+type pendingWrite struct {
+	l     *Logger
+	level Level
+	p     []byte
+}
+
+func NewThroughputLimiter(maxBytesPerSecond int64) *ThroughputLimiter {
+	t := &ThroughputLimiter{
+		maxBytesPerSecond: maxBytesPerSecond,
+		bucketStart:       time.Now(),
+	}
+	t.room = sync.NewCond(&t.mutex)
+	return t
+}
+
+// DropOnOverflow makes messages that do not fit in the overflow buffer be
+// discarded instead of blocking the caller. Discarded messages are counted
+// in the wrapped logger's Stats.
+func (t *ThroughputLimiter) DropOnOverflow(b bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.dropOnOverflow = b
+}
+
+// Wrap returns a child of l whose console output, including writers set with
+// SetLevelOutput, is subject to the limiter. Several loggers wrapped by the
+// same limiter share a single budget. Wrapping a logger that is already
+// limited replaces its limiter.
+func (t *ThroughputLimiter) Wrap(l *Logger) *Logger {
+	child := l.clone()
+	child.throughput = t
+	return child
+}
+
+// CurrentThroughput returns the rolling average in bytes/sec over the last second.
+func (t *ThroughputLimiter) CurrentThroughput() int64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.advance(time.Now())
+	return t.windowBytes()
+}
+
+// write sends p to l's console writer for level, or queues it when the
+// budget is spent. It is called without l's mutex held, so a caller waiting
+// for room in the overflow buffer does not block the logger's other methods.
+func (t *ThroughputLimiter) write(l *Logger, level Level, p []byte) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.advance(time.Now())
+	if len(t.overflow) == 0 && t.fits(int64(len(p))) {
+		t.buckets[t.bucketIdx] += int64(len(p))
+		return l.writeConsoleNow(level, p)
+	}
+
+	for !t.hasRoom(int64(len(p))) {
+		if t.dropOnOverflow {
+			return errDropped
+		}
+		t.room.Wait()
+	}
+	t.overflow = append(t.overflow, pendingWrite{l: l, level: level, p: p})
+	t.overflowBytes += int64(len(p))
+	if !t.draining {
+		t.draining = true
+		go t.drain()
+	}
+	return nil
+}
+
+// drain writes out queued messages as the window frees up and exits once the
+// overflow buffer is empty.
+func (t *ThroughputLimiter) drain() {
+	ticker := time.NewTicker(throughputBucketDur)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		t.mutex.Lock()
+		t.advance(time.Now())
+		for len(t.overflow) > 0 && t.fits(int64(len(t.overflow[0].p))) {
+			next := t.overflow[0]
+			t.overflow = t.overflow[1:]
+			t.overflowBytes -= int64(len(next.p))
+			t.buckets[t.bucketIdx] += int64(len(next.p))
+			next.l.writeConsoleNow(next.level, next.p)
+		}
+		t.room.Broadcast()
+		if len(t.overflow) == 0 {
+			t.draining = false
+			t.mutex.Unlock()
+			return
+		}
+		t.mutex.Unlock()
+	}
+}
+
+// advance rotates the sliding window forward to now, zeroing expired buckets.
+func (t *ThroughputLimiter) advance(now time.Time) {
+	elapsed := int(now.Sub(t.bucketStart) / throughputBucketDur)
+	if elapsed <= 0 {
+		return
+	}
+	if elapsed >= throughputBuckets {
+		t.buckets = [throughputBuckets]int64{}
+	} else {
+		for i := 0; i < elapsed; i++ {
+			t.bucketIdx = (t.bucketIdx + 1) % throughputBuckets
+			t.buckets[t.bucketIdx] = 0
+		}
+	}
+	t.bucketStart = t.bucketStart.Add(time.Duration(elapsed) * throughputBucketDur)
+}
+
+func (t *ThroughputLimiter) windowBytes() int64 {
+	var total int64
+	for _, b := range t.buckets {
+		total += b
+	}
+	return total
+}
+
+// fits reports whether n more bytes can be written without exceeding the
+// rate. A message larger than the whole budget still goes out once the
+// window is empty so it cannot be starved forever.
+func (t *ThroughputLimiter) fits(n int64) bool {
+	used := t.windowBytes()
+	return used == 0 || used+n <= t.maxBytesPerSecond
+}
+
+func (t *ThroughputLimiter) hasRoom(n int64) bool {
+	max := t.MaxOverflowBytes
+	if max <= 0 {
+		max = DefaultMaxOverflowBytes
+	}
+	return t.overflowBytes == 0 || t.overflowBytes+n <= max
+}
@@ -0,0 +1,139 @@
+package golog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type syncBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.String()
+}
+
+// waitForOutput polls buf until it contains substr, for up to five seconds.
+func waitForOutput(buf *syncBuffer, substr string) bool {
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if strings.Contains(buf.String(), substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestThroughputLimiterOverflow checks that messages over the budget are held back and drained later.
+func TestThroughputLimiterOverflow(t *testing.T) {
+	var buf syncBuffer
+	l := NewLogger()
+	l.w = &buf
+	limiter := NewThroughputLimiter(40)
+	limited := limiter.Wrap(l)
+
+	limited.Info("first 0123456789")
+	limited.Info("second 0123456789")
+	if got := strings.Count(buf.String(), "\n"); got != 1 {
+		t.Fatalf("expected 1 line before drain, got %d", got)
+	}
+	if limiter.CurrentThroughput() == 0 {
+		t.Error("expected non-zero throughput")
+	}
+
+	if !waitForOutput(&buf, "second") {
+		t.Errorf("expected overflow to be drained, got %q", buf.String())
+	}
+}
+
+// TestThroughputLimiterDrop checks that DropOnOverflow discards messages once the overflow buffer is full.
+func TestThroughputLimiterDrop(t *testing.T) {
+	var buf syncBuffer
+	l := NewLogger()
+	l.w = &buf
+	limiter := NewThroughputLimiter(40)
+	limiter.MaxOverflowBytes = 30
+	limiter.DropOnOverflow(true)
+	limited := limiter.Wrap(l)
+
+	limited.Info("first 0123456789")
+	limited.Info("second 0123456789")
+	limited.Info("third 0123456789")
+
+	if !waitForOutput(&buf, "second") {
+		t.Fatalf("expected overflow to be drained, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "third") {
+		t.Errorf("expected third message to be dropped, got %q", buf.String())
+	}
+	if stats := limited.Stats(); stats.Dropped != 1 || stats.ByLevel[LevelInfo] != 2 {
+		t.Errorf("expected 2 logged and 1 dropped, got %+v", stats)
+	}
+}
+
+// TestThroughputLimiterLevelOutput checks that per-level writers count against the budget.
+func TestThroughputLimiterLevelOutput(t *testing.T) {
+	var buf, errBuf syncBuffer
+	l := NewLogger(WithOutput(&buf))
+	l.SetLevelOutput(LevelError, &errBuf)
+	limiter := NewThroughputLimiter(40)
+	limited := limiter.Wrap(l)
+
+	limited.Error("first 0123456789")
+	limited.Error("second 0123456789")
+	if got := strings.Count(errBuf.String(), "\n"); got != 1 {
+		t.Fatalf("expected 1 line before drain, got %d", got)
+	}
+	if !waitForOutput(&errBuf, "second") {
+		t.Errorf("expected overflow to be drained to the level writer, got %q", errBuf.String())
+	}
+	if buf.String() != "" {
+		t.Errorf("expected nothing on the shared writer, got %q", buf.String())
+	}
+}
+
+// TestThroughputLimiterWaitUnlocked checks that a caller blocked on a full
+// overflow buffer does not hold the logger's mutex.
+func TestThroughputLimiterWaitUnlocked(t *testing.T) {
+	var buf syncBuffer
+	l := NewLogger(WithOutput(&buf))
+	limiter := NewThroughputLimiter(40)
+	limiter.MaxOverflowBytes = 30
+	limited := limiter.Wrap(l)
+
+	limited.Info("first 0123456789")
+	limited.Info("second 0123456789")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		limited.Info("third 0123456789")
+	}()
+
+	unlocked := make(chan struct{})
+	go func() {
+		limited.SetOutput(&buf)
+		close(unlocked)
+	}()
+	select {
+	case <-unlocked:
+	case <-done:
+		t.Fatal("expected the third message to wait for room")
+	case <-time.After(5 * time.Second):
+		t.Fatal("SetOutput blocked behind a waiting writer")
+	}
+	<-done
+	if !waitForOutput(&buf, "third") {
+		t.Errorf("expected the third message once there was room, got %q", buf.String())
+	}
+}
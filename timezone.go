@@ -0,0 +1,26 @@
+package golog
+
+import "time"
+
+func SetTimeZone(loc *time.Location) {
+	defaultLogger.SetTimeZone(loc)
+}
+
+// SetTimeZone renders timestamps, and names log files, in loc instead of
+// the local time zone. nil restores time.Local.
+func (l *Logger) SetTimeZone(loc *time.Location) {
+	l.Transact(func(cfg *LoggerConfig) { cfg.TimeZone = loc })
+}
+
+// now returns the current time in the configured zone.
+func (l *Logger) now() time.Time {
+	return l.settings().now()
+}
+
+// now returns the current time in cfg's zone.
+func (cfg *LoggerConfig) now() time.Time {
+	if cfg.TimeZone != nil {
+		return time.Now().In(cfg.TimeZone)
+	}
+	return time.Now()
+}
@@ -0,0 +1,69 @@
+// Package tracestore collects log entries by trace ID across loggers, so
+// integration tests can assert which services logged while handling a
+// request.
+package tracestore
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ryqdev/golog"
+)
+
+// TraceStore accumulates entries carrying a golog.CorrelationIDKey field.
+// Entries without one are ignored. It is safe for concurrent use.
+type TraceStore struct {
+	mutex   sync.Mutex
+	entries map[string][]golog.Entry
+	unsubs  []func()
+}
+
+func NewTraceStore() *TraceStore {
+	return &TraceStore{entries: make(map[string][]golog.Entry)}
+}
+
+// RegisterLogger records entries written by l and the loggers derived from
+// it, including ones created later with WithCorrelationID.
+func (ts *TraceStore) RegisterLogger(l *golog.Logger) {
+	unsub := l.Subscribe(ts.record)
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	ts.unsubs = append(ts.unsubs, unsub)
+}
+
+// ForTrace returns the entries logged under traceID, in the order they were
+// written.
+func (ts *TraceStore) ForTrace(traceID string) []golog.Entry {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	return append([]golog.Entry(nil), ts.entries[traceID]...)
+}
+
+// Reset forgets all recorded entries.
+func (ts *TraceStore) Reset() {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	ts.entries = make(map[string][]golog.Entry)
+}
+
+// Close stops recording from every registered logger.
+func (ts *TraceStore) Close() {
+	ts.mutex.Lock()
+	unsubs := ts.unsubs
+	ts.unsubs = nil
+	ts.mutex.Unlock()
+	for _, unsub := range unsubs {
+		unsub()
+	}
+}
+
+func (ts *TraceStore) record(e golog.Entry) {
+	id, ok := e.Fields[golog.CorrelationIDKey]
+	if !ok {
+		return
+	}
+	key := fmt.Sprint(id)
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	ts.entries[key] = append(ts.entries[key], e)
+}
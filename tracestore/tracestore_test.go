@@ -0,0 +1,37 @@
+package tracestore
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ryqdev/golog"
+)
+
+// TestForTrace checks that entries from several loggers are grouped by trace ID.
+func TestForTrace(t *testing.T) {
+	gateway := golog.NewLogger(golog.WithOutput(io.Discard))
+	billing := golog.NewLogger(golog.WithOutput(io.Discard))
+	ts := NewTraceStore()
+	defer ts.Close()
+	ts.RegisterLogger(gateway)
+	ts.RegisterLogger(billing)
+
+	gateway.WithCorrelationID("req-1").Info("received")
+	billing.WithCorrelationID("req-1").Info("charged")
+	billing.WithCorrelationID("req-2").Info("refunded")
+	gateway.Info("untraced")
+
+	entries := ts.ForTrace("req-1")
+	if len(entries) != 2 || entries[0].Message != "received" || entries[1].Message != "charged" {
+		t.Fatalf("unexpected entries for req-1: %+v", entries)
+	}
+	if n := len(ts.ForTrace("req-2")); n != 1 {
+		t.Errorf("expected 1 entry for req-2, got %d", n)
+	}
+
+	ts.Close()
+	gateway.WithCorrelationID("req-1").Info("after close")
+	if n := len(ts.ForTrace("req-1")); n != 2 {
+		t.Errorf("expected no entries recorded after Close, got %d", n)
+	}
+}
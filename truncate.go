@@ -0,0 +1,47 @@
+package golog
+
+import (
+	"io"
+	"os"
+)
+
+// SetDetectTruncation makes the file writer notice when the log file is
+// truncated by an external tool (e.g. logrotate's copytruncate) and reopen it.
+func (l *Logger) SetDetectTruncation(b bool) {
+	l.logFileMutex.Lock()
+	defer l.logFileMutex.Unlock()
+	l.detectTruncation = b
+}
+
+// checkTruncation compares the tracked write offset to the file's real
+// position and size, reopening the file if it has shrunk. The caller must
+// hold logFileMutex.
+func (l *Logger) checkTruncation() {
+	if l.gzipWriter != nil {
+		// The offset counts uncompressed bytes, and a truncated archive
+		// cannot be resumed anyway.
+		return
+	}
+	pos, err := l.logFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return
+	}
+	if l.preallocated {
+		// WriteAt does not move the file position.
+		pos = l.fileOffset
+	}
+	info, err := os.Stat(l.logFilePath)
+	if err == nil && pos >= l.fileOffset && info.Size() >= l.fileOffset {
+		return
+	}
+
+	l.logFile.Close()
+	l.preallocated = false
+	file, err := os.OpenFile(l.logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		l.logFile = nil
+		return
+	}
+	l.logFile = file
+	l.fileOffset, _ = file.Seek(0, io.SeekEnd)
+}
@@ -0,0 +1,37 @@
+package golog
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestDetectTruncation checks that entries written after an external truncation land in the reopened file.
+func TestDetectTruncation(t *testing.T) {
+	l := NewLogger()
+	l.SetLogDir(t.TempDir())
+	l.SetDetectTruncation(true)
+
+	l.writeToFile("before truncation\n")
+	path := l.logFilePath
+	defer l.logFile.Close()
+
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	l.writeToFile("after truncation\n")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "before truncation") {
+		t.Errorf("expected truncated content to be gone, got %q", content)
+	}
+	if !strings.Contains(string(content), "after truncation") {
+		t.Errorf("expected new entry in reopened file, got %q", content)
+	}
+	if l.fileOffset != int64(len(content)) {
+		t.Errorf("expected tracked offset %d, got %d", len(content), l.fileOffset)
+	}
+}
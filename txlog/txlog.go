@@ -0,0 +1,57 @@
+// Package txlog logs SQL transaction boundaries with structured fields.
+package txlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+// TxIDKey is the field key the transaction ID is logged under.
+const TxIDKey = "tx_id"
+
+type loggerKey struct{}
+
+// LogTxBegin logs the start of txID at Debug and returns a context carrying a
+// child of l with the tx_id field attached. Retrieve it with Logger so later
+// messages inside the transaction are tagged too.
+func LogTxBegin(ctx context.Context, l *golog.Logger, txID string) context.Context {
+	txLogger := l.WithTypedFields(golog.Field{Key: TxIDKey, Value: txID})
+	txLogger.Debug("transaction begin")
+	return context.WithValue(ctx, loggerKey{}, txLogger)
+}
+
+// LogTxCommit logs a successful commit of txID at Info.
+func LogTxCommit(ctx context.Context, l *golog.Logger, txID string, duration time.Duration) {
+	txLogger(ctx, l, txID).
+		WithTypedFields(golog.Field{Key: "duration_ms", Value: duration.Milliseconds()}).
+		Info("transaction commit")
+}
+
+// LogTxRollback logs a rollback of txID with its reason at Warn.
+func LogTxRollback(ctx context.Context, l *golog.Logger, txID string, reason error, duration time.Duration) {
+	fields := []golog.Field{{Key: "duration_ms", Value: duration.Milliseconds()}}
+	if reason != nil {
+		fields = append(fields, golog.Field{Key: "reason", Value: reason.Error()})
+	}
+	txLogger(ctx, l, txID).WithTypedFields(fields...).Warn("transaction rollback")
+}
+
+// Logger returns the transaction logger stored by LogTxBegin, or fallback if
+// ctx does not carry one.
+func Logger(ctx context.Context, fallback *golog.Logger) *golog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*golog.Logger); ok {
+		return l
+	}
+	return fallback
+}
+
+// txLogger returns the logger from ctx, or l tagged with txID when ctx was
+// not produced by LogTxBegin.
+func txLogger(ctx context.Context, l *golog.Logger, txID string) *golog.Logger {
+	if tl, ok := ctx.Value(loggerKey{}).(*golog.Logger); ok {
+		return tl
+	}
+	return l.WithTypedFields(golog.Field{Key: TxIDKey, Value: txID})
+}
@@ -0,0 +1,49 @@
+package txlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryqdev/golog"
+)
+
+// TestTransactionLifecycle checks the level and fields of each boundary message.
+func TestTransactionLifecycle(t *testing.T) {
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf))
+	l.SetLevel(golog.LevelDebug)
+
+	ctx := LogTxBegin(context.Background(), l, "tx42")
+	Logger(ctx, l).Info("inserting row")
+	LogTxRollback(ctx, l, "tx42", errors.New("deadlock"), 15*time.Millisecond)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "tx_id=tx42") {
+			t.Errorf("expected tx_id field in %q", line)
+		}
+	}
+	if !strings.Contains(lines[0], "[DEBUG]") || !strings.Contains(lines[0], "transaction begin") {
+		t.Errorf("unexpected begin line %q", lines[0])
+	}
+	if !strings.Contains(lines[2], "[WARN]") || !strings.Contains(lines[2], "reason=deadlock") || !strings.Contains(lines[2], "duration_ms=15") {
+		t.Errorf("unexpected rollback line %q", lines[2])
+	}
+}
+
+// TestCommitWithoutBegin checks that commit tags the message even without a transaction context.
+func TestCommitWithoutBegin(t *testing.T) {
+	var buf bytes.Buffer
+	l := golog.NewLogger(golog.WithOutput(&buf))
+	LogTxCommit(context.Background(), l, "tx7", time.Second)
+	if out := buf.String(); !strings.Contains(out, "[INFO]") || !strings.Contains(out, "tx_id=tx7") || !strings.Contains(out, "duration_ms=1000") {
+		t.Errorf("unexpected commit line %q", out)
+	}
+}
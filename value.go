@@ -0,0 +1,33 @@
+package golog
+
+// InfoVal logs val with format at Info and returns it, so a value can be
+// logged inline: cfg := golog.InfoVal(l, "loaded config: %v", loadConfig()).
+func InfoVal[T any](l *Logger, format string, val T) T {
+	l.log(LevelInfo, format, val)
+	return val
+}
+
+// DebugVal is InfoVal at Debug.
+func DebugVal[T any](l *Logger, format string, val T) T {
+	l.log(LevelDebug, format, val)
+	return val
+}
+
+// WarnVal is InfoVal at Warn.
+func WarnVal[T any](l *Logger, format string, val T) T {
+	l.log(LevelWarn, format, val)
+	return val
+}
+
+// ErrorVal is InfoVal at Error.
+func ErrorVal[T any](l *Logger, format string, val T) T {
+	l.log(LevelError, format, val)
+	return val
+}
+
+// InfoAny is the method form of InfoVal, for callers that prefer method
+// syntax over keeping the static type.
+func (l *Logger) InfoAny(format string, val any) any {
+	l.log(LevelInfo, format, val)
+	return val
+}
@@ -0,0 +1,38 @@
+package golog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestInfoVal checks that the value is returned unchanged and logged.
+func TestInfoVal(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithOutput(&buf), WithShowDetail(true))
+
+	type config struct{ Port int }
+	got := InfoVal(l, "loaded config: %+v", config{Port: 8080})
+	if got.Port != 8080 {
+		t.Errorf("expected returned value to be unchanged, got %+v", got)
+	}
+	if out := buf.String(); !strings.Contains(out, "loaded config: {Port:8080}") || !strings.Contains(out, "value_test.go:") {
+		t.Errorf("expected value and caller in output, got %q", out)
+	}
+
+	buf.Reset()
+	if v := l.InfoAny("count %d", 3); v != 3 {
+		t.Errorf("expected 3, got %v", v)
+	}
+	if !strings.Contains(buf.String(), "count 3") {
+		t.Errorf("expected InfoAny output, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if v := DebugVal(l, "hidden %s", "x"); v != "x" {
+		t.Errorf("expected filtered DebugVal to still return its value, got %q", v)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected Debug to be filtered at Info, got %q", buf.String())
+	}
+}
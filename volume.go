@@ -0,0 +1,61 @@
+package golog
+
+import (
+	"sync"
+	"time"
+)
+
+// VolumeAlertBucket calls Fn once MaxMessages messages at or above Level
+// have been written within Window. The count starts over after each call.
+type VolumeAlertBucket struct {
+	Window      time.Duration
+	MaxMessages int
+	Level       Level
+	Fn          func(count int)
+}
+
+type volumeAlerter struct {
+	mutex   sync.Mutex
+	now     func() time.Time
+	buckets []VolumeAlertBucket
+	times   [][]time.Time // Per bucket, timestamps of messages still in its window
+}
+
+// SetVolumeAlertBuckets replaces the volume alerts for l and the loggers
+// derived from it. Several buckets allow tiered alerts, e.g. one at 1000
+// errors a minute and another at 5000. Fn runs in its own goroutine so it
+// cannot stall logging. A nil slice removes all alerts.
+func (l *Logger) SetVolumeAlertBuckets(buckets []VolumeAlertBucket) {
+	if len(buckets) == 0 {
+		l.stats.alerter.Store(nil)
+		return
+	}
+	l.stats.alerter.Store(&volumeAlerter{
+		now:     time.Now,
+		buckets: append([]VolumeAlertBucket(nil), buckets...),
+		times:   make([][]time.Time, len(buckets)),
+	})
+}
+
+func (a *volumeAlerter) observe(level Level) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	now := a.now()
+	for i, b := range a.buckets {
+		if level < b.Level {
+			continue
+		}
+		times := a.times[i]
+		expired := 0
+		for expired < len(times) && now.Sub(times[expired]) >= b.Window {
+			expired++
+		}
+		times = append(times[expired:], now)
+		if len(times) >= b.MaxMessages {
+			go b.Fn(len(times))
+			times = times[:0]
+		}
+		a.times[i] = times
+	}
+}
@@ -0,0 +1,58 @@
+package golog
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+// TestVolumeAlertBuckets checks tiered alerts, the level filter and the reset after firing.
+func TestVolumeAlertBuckets(t *testing.T) {
+	fired := make(chan [2]int, 10)
+	l := NewLogger(WithOutput(io.Discard))
+	l.SetVolumeAlertBuckets([]VolumeAlertBucket{
+		{Window: time.Minute, MaxMessages: 3, Level: LevelError, Fn: func(n int) { fired <- [2]int{0, n} }},
+		{Window: time.Minute, MaxMessages: 6, Level: LevelError, Fn: func(n int) { fired <- [2]int{1, n} }},
+	})
+
+	for i := 0; i < 6; i++ {
+		l.Info("ignored")
+		l.Error("counted")
+	}
+
+	got := map[int]int{}
+	for i := 0; i < 3; i++ {
+		select {
+		case f := <-fired:
+			got[f[0]]++
+			if f[1] < 3 {
+				t.Errorf("expected count of at least the threshold, got %d", f[1])
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected alert to fire")
+		}
+	}
+	if got[0] != 2 || got[1] != 1 {
+		t.Errorf("expected the low tier twice and the high tier once, got %v", got)
+	}
+}
+
+// TestVolumeAlertWindow checks that messages outside the window do not count.
+func TestVolumeAlertWindow(t *testing.T) {
+	clock := time.Date(2024, 9, 17, 12, 0, 0, 0, time.UTC)
+	var count int
+	a := &volumeAlerter{
+		now:     func() time.Time { return clock },
+		buckets: []VolumeAlertBucket{{Window: time.Minute, MaxMessages: 2, Level: LevelInfo, Fn: func(n int) { count = n }}},
+		times:   make([][]time.Time, 1),
+	}
+	a.observe(LevelInfo)
+	clock = clock.Add(2 * time.Minute)
+	a.observe(LevelInfo)
+	if len(a.times[0]) != 1 {
+		t.Errorf("expected the expired message to be dropped from the window, got %d", len(a.times[0]))
+	}
+	if count != 0 {
+		t.Errorf("expected no alert, got %d", count)
+	}
+}
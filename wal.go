@@ -0,0 +1,44 @@
+package golog
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WALFileName is the journal SetWALMode keeps in the log directory.
+const WALFileName = "wal.log"
+
+// SetWALMode journals every line queued for the log file in wal.log in the
+// log directory, as SetJournalFile does, so lines lost in a crash are
+// written on the next start. Turning it on first recovers what the previous
+// run left behind. Errors are reported on stderr.
+func (l *Logger) SetWALMode(b bool) {
+	if !b {
+		l.Flush()
+		l.logFileMutex.Lock()
+		defer l.logFileMutex.Unlock()
+		if l.journal != nil {
+			l.journal.file.Close()
+			l.journal = nil
+		}
+		return
+	}
+
+	l.logFileMutex.Lock()
+	dir := l.logDirOrDefault()
+	l.logFileMutex.Unlock()
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		l.reportError(err)
+		return
+	}
+	if err := l.SetJournalFile(filepath.Join(dir, WALFileName)); err != nil {
+		l.reportError(err)
+	}
+}
+
+// RecoverWAL writes the lines left uncommitted in the WAL in dir to l's log
+// file and returns how many there were. SetWALMode does this itself; call
+// RecoverWAL to recover without turning WAL mode on.
+func RecoverWAL(dir string, l *Logger) (int, error) {
+	return RecoverJournal(filepath.Join(dir, WALFileName), l)
+}
@@ -0,0 +1,36 @@
+package golog
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWALMode checks that a line queued but never written is replayed on the next start.
+func TestWALMode(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLogger(WithOutput(io.Discard))
+	l.SetLogDir(dir)
+	l.setWriteLogToFile(true) // No writer goroutine: the line stays queued as if it crashed
+	l.SetWALMode(true)
+	l.Info("in flight")
+
+	if _, err := os.Stat(filepath.Join(dir, WALFileName)); err != nil {
+		t.Fatalf("expected a WAL in the log directory: %v", err)
+	}
+
+	restarted := NewLogger()
+	restarted.SetLogDir(dir)
+	n, err := RecoverWAL(dir, restarted)
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 recovered line, got %d (%v)", n, err)
+	}
+	restarted.closeLogFile()
+	content, _ := os.ReadFile(filepath.Join(dir, time.Now().Format(DefaultFilePattern)))
+	if !strings.Contains(string(content), "in flight") {
+		t.Errorf("expected the recovered line in the log file, got %q", content)
+	}
+}